@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/joshuarubin/go-sway/command"
 	"github.com/joshuarubin/lifecycle"
 	"go.uber.org/multierr"
 )
@@ -62,6 +64,10 @@ type Client interface {
 	GetSeats(context.Context) ([]Seat, error)
 }
 
+// ErrSubscribeUnsuccessful is returned by Subscribe when sway rejects the
+// subscription, e.g. because it does not support one of the event types
+var ErrSubscribeUnsuccessful = errors.New("subscribe unsuccessful")
+
 // Option can be passed to New to specify runtime configuration settings
 type Option func(*client)
 
@@ -180,7 +186,7 @@ func (c *client) subscribe(ctx context.Context, events ...EventType) error {
 	}
 
 	if !reply.Success {
-		return fmt.Errorf("subscribe unsuccessful")
+		return ErrSubscribeUnsuccessful
 	}
 
 	return nil
@@ -206,6 +212,59 @@ func (c *client) RunCommand(ctx context.Context, command string) ([]RunCommandRe
 	return replies, err
 }
 
+// RunCommands runs several command strings as a single message with
+// c.RunCommand and maps each reply back to the command that produced it. Each
+// command string is checked before they are joined, so one that ends inside of
+// a quote or criteria can't swallow the commands after it.
+func RunCommands(ctx context.Context, c Client, cmds ...string) ([]RunCommandResult, error) {
+	var results []RunCommandResult
+	for i, cmd := range cmds {
+		if err := command.CheckTerminated(cmd); err != nil {
+			return nil, fmt.Errorf("command %d: %v", i, err)
+		}
+
+		for _, single := range command.SplitCommands(cmd) {
+			results = append(results, RunCommandResult{Index: i, Command: single})
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	// RunCommand returns the replies along with an error for the unsuccessful
+	// commands, which is replaced by the CommandErrors below
+	replies, err := c.RunCommand(ctx, strings.Join(cmds, "; "))
+	if len(replies) == 0 && err != nil {
+		return nil, err
+	}
+
+	var errs CommandErrors
+	for i := range results {
+		if i >= len(replies) {
+			break
+		}
+
+		results[i].Ran = true
+		results[i].Reply = replies[i]
+
+		if !replies[i].Success {
+			errs = append(errs, CommandError{
+				Index:      results[i].Index,
+				Command:    results[i].Command,
+				Message:    replies[i].Error,
+				ParseError: replies[i].ParseError,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return results, errs
+	}
+
+	return results, nil
+}
+
 func (c *client) GetWorkspaces(ctx context.Context) ([]Workspace, error) {
 	msg, err := c.roundTrip(ctx, messageTypeGetWorkspaces, nil)
 	if err != nil {
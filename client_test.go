@@ -0,0 +1,77 @@
+package sway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestRunCommands(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeSway(t, func(_ *fakeSway, typ uint32, payload string) string {
+		// the move fails and bogus can't be parsed, so sway stops before
+		// running kill
+		return `[{"success":true},{"success":true},{"success":false,"error":"No workspace"},{"success":false,"parse_error":true,"error":"Unknown command"}]`
+	})
+
+	results, err := sway.RunCommands(ctx, f.client(ctx, t), `[title="a;b"] kill`, "[app_id=x] focus, move to workspace foo", "bogus; kill")
+
+	if got, want := f.Commands(), []string{`[title="a;b"] kill; [app_id=x] focus, move to workspace foo; bogus; kill`}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+
+	var errs sway.CommandErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 5 {
+		t.Fatalf("got %d results, want 5", len(results))
+	}
+
+	for i, want := range []struct {
+		index   int
+		command string
+		ran     bool
+	}{
+		{0, `[title="a;b"] kill`, true},
+		{1, "[app_id=x] focus", true},
+		{1, "move to workspace foo", true},
+		{2, "bogus", true},
+		{2, "kill", false},
+	} {
+		if r := results[i]; r.Index != want.index || r.Command != want.command || r.Ran != want.ran {
+			t.Errorf("result %d: got %+v, want %+v", i, r, want)
+		}
+	}
+
+	if len(errs) != 2 || errs[0].Index != 1 || errs[0].ParseError || errs[1].Index != 2 || !errs[1].ParseError {
+		t.Errorf("unexpected errors: %+v", errs)
+	}
+
+	if got := errs.Indices(); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Indices: got %v", got)
+	}
+}
+
+func TestRunCommandsUnterminated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeSway(t, func(_ *fakeSway, typ uint32, payload string) string {
+		return `[{"success":true}]`
+	})
+
+	// joined as is, the quote would swallow the kill
+	if _, err := sway.RunCommands(ctx, f.client(ctx, t), `exec echo 'unterminated`, "kill"); err == nil {
+		t.Error("expected an error")
+	}
+
+	if got := f.Commands(); len(got) != 0 {
+		t.Errorf("sent %q, want no commands", got)
+	}
+}
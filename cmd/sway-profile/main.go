@@ -0,0 +1,92 @@
+// Command sway-profile applies display profiles when outputs are connected or
+// disconnected. See package profile for the format of the profiles file.
+//
+// The profiles are read again when SIGHUP is received.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	sway "github.com/joshuarubin/go-sway"
+	"github.com/joshuarubin/go-sway/profile"
+	"github.com/joshuarubin/lifecycle"
+)
+
+func defaultPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		dir = filepath.Join(os.Getenv("HOME"), ".config")
+	}
+	return filepath.Join(dir, "sway", "profiles")
+}
+
+func main() {
+	path := flag.String("config", defaultPath(), "path of the profiles file")
+	interval := flag.Duration("poll", 5*time.Second, "how often to check the outputs if sway does not send output events")
+	flag.Parse()
+
+	if err := run(*path, *interval); err != nil {
+		if _, ok := err.(lifecycle.ErrSignal); ok {
+			return
+		}
+		log.Fatal(err)
+	}
+}
+
+func run(path string, interval time.Duration) error {
+	ctx := lifecycle.New(context.Background())
+
+	profiles, err := profile.Load(path)
+	if err != nil {
+		return err
+	}
+
+	client, err := sway.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	m := profile.NewManager(client, profiles, func(_ context.Context, m *profile.Match, err error) {
+		if m != nil {
+			log.Printf("applied profile %q", m.Profile.Name)
+		}
+
+		if err != nil {
+			log.Print(err)
+		}
+	})
+
+	lifecycle.GoErr(ctx, func() error {
+		return m.Run(ctx, interval)
+	})
+
+	lifecycle.Go(ctx, func() {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				profiles, err := profile.Load(path)
+				if err != nil {
+					log.Print(err)
+					continue
+				}
+
+				m.SetProfiles(ctx, profiles)
+			}
+		}
+	})
+
+	return lifecycle.Wait(ctx)
+}
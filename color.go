@@ -0,0 +1,119 @@
+package sway
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Color is a non-alpha-premultiplied color as used by sway. It is encoded as
+// #RRGGBBAA and can be decoded from #RGB, #RRGGBB, or #RRGGBBAA.
+type Color color.NRGBA
+
+// ParseColor parses a color in one of the #RGB, #RRGGBB, or #RRGGBBAA forms.
+// The leading "#" is optional. Colors without an alpha component are opaque.
+func ParseColor(s string) (Color, error) {
+	hex := strings.TrimPrefix(s, "#")
+
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]}) + "ff"
+	case 6:
+		hex += "ff"
+	case 8:
+	default:
+		return Color{}, fmt.Errorf("invalid color %q", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid color %q", s)
+	}
+
+	return Color{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: uint8(v),
+	}, nil
+}
+
+// String returns c as #rrggbbaa
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// RGBA implements color.Color
+func (c Color) RGBA() (r, g, b, a uint32) {
+	return color.NRGBA(c).RGBA()
+}
+
+// ToRGBA converts c to an alpha-premultiplied color.RGBA
+func (c Color) ToRGBA() color.RGBA {
+	return color.RGBAModel.Convert(c).(color.RGBA)
+}
+
+// ColorFrom converts any color.Color to a Color
+func ColorFrom(c color.Color) Color {
+	return Color(color.NRGBAModel.Convert(c).(color.NRGBA))
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty string results
+// in the zero Color.
+func (c *Color) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = Color{}
+		return nil
+	}
+
+	v, err := ParseColor(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = v
+	return nil
+}
+
+// Config returns the colors as a "colors" block that can be used inside of a
+// "bar" block in the sway config. Settings with colors that are nil are left
+// out.
+func (c BarConfigColors) Config() string {
+	var b strings.Builder
+
+	line := func(name string, colors ...*Color) {
+		for _, c := range colors {
+			if c == nil {
+				return
+			}
+		}
+
+		b.WriteString("\t" + name)
+		for _, c := range colors {
+			b.WriteString(" " + c.String())
+		}
+		b.WriteByte('\n')
+	}
+
+	b.WriteString("colors {\n")
+	line("background", c.Background)
+	line("statusline", c.Statusline)
+	line("separator", c.Separator)
+	line("focused_background", c.FocusedBackground)
+	line("focused_statusline", c.FocusedStatusline)
+	line("focused_separator", c.FocusedSeparator)
+	line("focused_workspace", c.FocusedWorkspaceBorder, c.FocusedWorkspaceBG, c.FocusedWorkspaceText)
+	line("active_workspace", c.ActiveWorkspaceBorder, c.ActiveWorkspaceBG, c.ActiveWorkspaceText)
+	line("inactive_workspace", c.InactiveWorkspaceBorder, c.InactiveWorkspaceBG, c.InactiveWorkspaceText)
+	line("urgent_workspace", c.UrgentWorkspaceBorder, c.UrgentWorkspaceBG, c.UrgentWorkspaceText)
+	line("binding_mode", c.BindingModeBorder, c.BindingModeBG, c.BindingModeText)
+	b.WriteString("}\n")
+
+	return b.String()
+}
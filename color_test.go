@@ -0,0 +1,69 @@
+package sway_test
+
+import (
+	"encoding/json"
+	"image/color"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestParseColor(t *testing.T) {
+	for in, want := range map[string]string{
+		"#f0a":      "#ff00aaff",
+		"#FF00AA":   "#ff00aaff",
+		"ff00aa80":  "#ff00aa80",
+		"#00000000": "#00000000",
+	} {
+		c, err := sway.ParseColor(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+
+		if got := c.String(); got != want {
+			t.Errorf("%q: got %q, want %q", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "#", "#ff00a", "#gg0000", "#ff00aa8000"} {
+		if _, err := sway.ParseColor(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+
+	c, _ := sway.ParseColor("#ff000080")
+	if got, want := c.ToRGBA(), (color.RGBA{R: 0x80, A: 0x80}); got != want {
+		t.Errorf("ToRGBA: got %v, want %v", got, want)
+	}
+
+	if got := sway.ColorFrom(color.RGBA{R: 0x80, A: 0x80}); got != c {
+		t.Errorf("ColorFrom: got %v, want %v", got, c)
+	}
+}
+
+func TestBarConfigColors(t *testing.T) {
+	var bar sway.BarConfig
+	if err := json.Unmarshal([]byte(`{"id":"bar-0","colors":{"background":"#323232ff","focused_workspace_border":"#4c7899","focused_workspace_bg":"#285577ff","focused_workspace_text":"#fff"}}`), &bar); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := bar.Colors.FocusedWorkspaceBorder.String(); got != "#4c7899ff" {
+		t.Errorf("focused_workspace_border: got %q", got)
+	}
+
+	out, err := json.Marshal(bar.Colors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// colors that sway did not send are not written as #00000000
+	if want := `{"background":"#323232ff","focused_workspace_text":"#ffffffff","focused_workspace_bg":"#285577ff","focused_workspace_border":"#4c7899ff"}`; string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+
+	want := "colors {\n\tbackground #323232ff\n\tfocused_workspace #4c7899ff #285577ff #ffffffff\n}\n"
+	if cfg := bar.Colors.Config(); cfg != want {
+		t.Errorf("unexpected config:\n%s", cfg)
+	}
+}
@@ -0,0 +1,339 @@
+package command
+
+import "strconv"
+
+// Direction is a direction on screen
+type Direction string
+
+const (
+	Left  Direction = "left"
+	Right Direction = "right"
+	Up    Direction = "up"
+	Down  Direction = "down"
+)
+
+// State is the argument of commands that turn a setting on or off
+type State string
+
+const (
+	Enable  State = "enable"
+	Disable State = "disable"
+	Toggle  State = "toggle"
+)
+
+// Layout is a container layout
+type Layout string
+
+const (
+	LayoutDefault Layout = "default"
+	LayoutSplitH  Layout = "splith"
+	LayoutSplitV  Layout = "splitv"
+	LayoutStacked Layout = "stacking"
+	LayoutTabbed  Layout = "tabbed"
+)
+
+// SplitMode is the argument of the split command
+type SplitMode string
+
+const (
+	SplitHorizontal SplitMode = "horizontal"
+	SplitVertical   SplitMode = "vertical"
+	SplitToggle     SplitMode = "toggle"
+	SplitNone       SplitMode = "none"
+)
+
+// Unit is the unit of a resize amount
+type Unit string
+
+const (
+	// Px is a size in logical pixels. It is used for floating containers.
+	Px Unit = "px"
+
+	// Ppt is a size in percentage points of the parent. It is used for tiled
+	// containers.
+	Ppt Unit = "ppt"
+)
+
+// Size is an amount used with resize commands
+type Size struct {
+	Value int64
+	Unit  Unit
+}
+
+func (s Size) args() []string {
+	args := []string{strconv.FormatInt(s.Value, 10)}
+	if s.Unit != "" {
+		args = append(args, string(s.Unit))
+	}
+	return args
+}
+
+// Focus focuses the containers matched by the command's criteria
+func Focus() Command {
+	return New("focus")
+}
+
+// FocusDirection moves focus to the container in direction d
+func FocusDirection(d Direction) Command {
+	return New("focus", string(d))
+}
+
+// FocusOutput moves focus to the named output or to the output in a direction
+// such as "left"
+func FocusOutput(name string) Command {
+	return New("focus", "output", name)
+}
+
+// FocusParent moves focus to the parent container
+func FocusParent() Command {
+	return New("focus", "parent")
+}
+
+// FocusChild moves focus to the last focused child container
+func FocusChild() Command {
+	return New("focus", "child")
+}
+
+// FocusModeToggle moves focus between the tiling and floating layers
+func FocusModeToggle() Command {
+	return New("focus", "mode_toggle")
+}
+
+// Move moves the focused container in direction d. If px is greater than 0,
+// floating containers are moved by that many pixels.
+func Move(d Direction, px int64) Command {
+	c := New("move", string(d))
+	if px > 0 {
+		c.Args = append(c.Args, strconv.FormatInt(px, 10), "px")
+	}
+	return c
+}
+
+// MoveToWorkspace moves the focused container to the named workspace
+func MoveToWorkspace(name string) Command {
+	return New("move", "container", "to", "workspace", name)
+}
+
+// MoveToWorkspaceNumber moves the focused container to the workspace with
+// the given number
+func MoveToWorkspaceNumber(num int64) Command {
+	return New("move", "container", "to", "workspace", "number", strconv.FormatInt(num, 10))
+}
+
+// MoveToOutput moves the focused container to the named output or to the
+// output in a direction such as "left"
+func MoveToOutput(name string) Command {
+	return New("move", "container", "to", "output", name)
+}
+
+// MoveToMark moves the focused container to the container with the given mark
+func MoveToMark(mark string) Command {
+	return New("move", "container", "to", "mark", mark)
+}
+
+// MoveToScratchpad moves the focused container to the scratchpad
+func MoveToScratchpad() Command {
+	return New("move", "scratchpad")
+}
+
+// MoveWorkspaceToOutput moves the focused workspace to the named output or to
+// the output in a direction such as "left"
+func MoveWorkspaceToOutput(name string) Command {
+	return New("move", "workspace", "to", "output", name)
+}
+
+// MovePosition moves the focused floating container to the given absolute
+// position
+func MovePosition(x, y int64) Command {
+	return New("move", "absolute", "position", strconv.FormatInt(x, 10), strconv.FormatInt(y, 10))
+}
+
+// SetLayout sets the layout of the focused container
+func SetLayout(l Layout) Command {
+	return New("layout", string(l))
+}
+
+// LayoutToggle cycles the layout of the focused container through the given
+// layouts. Without layouts, it toggles between stacking, tabbed and the last
+// split layout.
+func LayoutToggle(layouts ...Layout) Command {
+	c := New("layout", "toggle")
+	for _, l := range layouts {
+		c.Args = append(c.Args, string(l))
+	}
+	return c
+}
+
+// Split splits the focused container
+func Split(m SplitMode) Command {
+	return New("split", string(m))
+}
+
+// Workspace switches to the named workspace, creating it if it does not exist
+func Workspace(name string) Command {
+	return New("workspace", name)
+}
+
+// WorkspaceNumber switches to the workspace with the given number, creating
+// it if it does not exist
+func WorkspaceNumber(num int64) Command {
+	return New("workspace", "number", strconv.FormatInt(num, 10))
+}
+
+// WorkspaceNext switches to the next workspace. When onOutput is true, only
+// workspaces on the focused output are considered.
+func WorkspaceNext(onOutput bool) Command {
+	if onOutput {
+		return New("workspace", "next_on_output")
+	}
+	return New("workspace", "next")
+}
+
+// WorkspacePrev switches to the previous workspace. When onOutput is true,
+// only workspaces on the focused output are considered.
+func WorkspacePrev(onOutput bool) Command {
+	if onOutput {
+		return New("workspace", "prev_on_output")
+	}
+	return New("workspace", "prev")
+}
+
+// WorkspaceBackAndForth switches to the previously focused workspace
+func WorkspaceBackAndForth() Command {
+	return New("workspace", "back_and_forth")
+}
+
+// RenameWorkspace renames a workspace. If from is empty, the focused workspace
+// is renamed.
+func RenameWorkspace(from, to string) Command {
+	c := New("rename", "workspace")
+	if from != "" {
+		c.Args = append(c.Args, from)
+	}
+	c.Args = append(c.Args, "to", to)
+	return c
+}
+
+// MarkOption changes how Mark behaves
+type MarkOption string
+
+const (
+	// MarkAdd adds the mark instead of replacing the existing marks
+	MarkAdd MarkOption = "--add"
+
+	// MarkToggle removes the mark if it is already set
+	MarkToggle MarkOption = "--toggle"
+)
+
+// Mark sets a mark on the focused container
+func Mark(mark string, opts ...MarkOption) Command {
+	c := New("mark")
+	for _, opt := range opts {
+		c.Args = append(c.Args, string(opt))
+	}
+	c.Args = append(c.Args, mark)
+	return c
+}
+
+// Unmark removes a mark from the container that has it. If mark is empty, all
+// marks are removed.
+func Unmark(mark string) Command {
+	if mark == "" {
+		return New("unmark")
+	}
+	return New("unmark", mark)
+}
+
+// ResizeGrow grows the width or height of the focused container. The
+// dimension can be "width", "height", "horizontal", or "vertical".
+func ResizeGrow(dimension string, amount Size) Command {
+	return New("resize", append([]string{"grow", dimension}, amount.args()...)...)
+}
+
+// ResizeShrink shrinks the width or height of the focused container. The
+// dimension can be "width", "height", "horizontal", or "vertical".
+func ResizeShrink(dimension string, amount Size) Command {
+	return New("resize", append([]string{"shrink", dimension}, amount.args()...)...)
+}
+
+// ResizeSet sets the size of the focused container. A zero Size leaves that
+// dimension unchanged.
+func ResizeSet(width, height Size) Command {
+	c := New("resize", "set")
+	if width.Value != 0 {
+		c.Args = append(append(c.Args, "width"), width.args()...)
+	}
+	if height.Value != 0 {
+		c.Args = append(append(c.Args, "height"), height.args()...)
+	}
+	return c
+}
+
+// SetFloating makes the focused container floating or tiled
+func SetFloating(s State) Command {
+	return New("floating", string(s))
+}
+
+// Fullscreen makes the focused container fullscreen on its output, or on all
+// outputs when global is true
+func Fullscreen(s State, global bool) Command {
+	c := New("fullscreen", string(s))
+	if global {
+		c.Args = append(c.Args, "global")
+	}
+	return c
+}
+
+// Sticky makes the focused floating container show on all workspaces
+func Sticky(s State) Command {
+	return New("sticky", string(s))
+}
+
+// Exec runs a program with the given arguments. Each argument is quoted for
+// the shell so it is passed to the program unchanged.
+func Exec(argv ...string) Command {
+	return New("exec", argv...)
+}
+
+// ExecShell runs a shell script with sh -c
+func ExecShell(script string) Command {
+	return Exec("sh", "-c", script)
+}
+
+// Kill closes the focused container and all of its children
+func Kill() Command {
+	return New("kill")
+}
+
+// ScratchpadShow shows the most recently hidden scratchpad window, or hides
+// it if it is already visible
+func ScratchpadShow() Command {
+	return New("scratchpad", "show")
+}
+
+// Output configures the named output, or all outputs when name is "*". See
+// sway-output(5) for the available settings.
+func Output(name string, args ...string) Command {
+	return New("output", append([]string{name}, args...)...)
+}
+
+// Input configures the input device with the given identifier. The
+// identifier can also be "*" or a device type such as "type:touchpad". See
+// sway-input(5) for the available settings.
+func Input(identifier string, args ...string) Command {
+	return New("input", append([]string{identifier}, args...)...)
+}
+
+// Bar configures the bar with the given ID. See sway-bar(5) for the settings
+// that can be changed at runtime.
+func Bar(id string, args ...string) Command {
+	return New("bar", append([]string{id}, args...)...)
+}
+
+// Nop does nothing. The comment is ignored by sway.
+func Nop(comment string) Command {
+	if comment == "" {
+		return New("nop")
+	}
+	return New("nop", comment)
+}
@@ -0,0 +1,134 @@
+// Package command builds sway commands, as passed to Client.RunCommand, from
+// typed arguments. Arguments and criteria values are quoted and escaped so that
+// user provided strings such as window titles and workspace names can never
+// end a command early or inject additional commands. Parse does the reverse and
+// turns command strings back into commands.
+package command
+
+import (
+	"strings"
+)
+
+// A Command is a single sway command, optionally limited by criteria
+type Command struct {
+	// Criteria select the containers the command applies to. Without criteria,
+	// the command applies to the focused container.
+	Criteria Criteria
+
+	// Name is the command name, e.g. "focus" or "move"
+	Name string
+
+	// Args are the unquoted arguments of the command
+	Args []string
+}
+
+// New returns a Command with the given name and unquoted arguments
+func New(name string, args ...string) Command {
+	return Command{Name: name, Args: args}
+}
+
+// With returns a copy of c that also has the given criteria
+func (c Command) With(criteria ...Criterion) Command {
+	c.Criteria = append(append(Criteria{}, c.Criteria...), criteria...)
+	return c
+}
+
+// String returns the command with all arguments quoted as needed
+func (c Command) String() string {
+	var b strings.Builder
+
+	if len(c.Criteria) > 0 {
+		b.WriteString(c.Criteria.String())
+		b.WriteByte(' ')
+	}
+
+	b.WriteString(c.Name)
+
+	if isExec(c.Name) {
+		if len(c.Args) > 0 {
+			b.WriteByte(' ')
+			b.WriteString(execArgs(c.Args))
+		}
+		return b.String()
+	}
+
+	for _, arg := range c.Args {
+		b.WriteByte(' ')
+		b.WriteString(Quote(arg))
+	}
+
+	return b.String()
+}
+
+// List is a sequence of commands that sway runs in order
+type List []Command
+
+// String returns the commands separated by semicolons
+func (l List) String() string {
+	s := make([]string, len(l))
+	for i, c := range l {
+		s[i] = c.String()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Join returns the given commands as a single command string
+func Join(cmds ...Command) string {
+	return List(cmds).String()
+}
+
+func isExec(name string) bool {
+	return name == "exec" || name == "exec_always"
+}
+
+// needsQuote returns true if s can't be passed to sway as a bare word
+func needsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	return strings.ContainsAny(s, " \t\r\n;,\"'[]{}\\#")
+}
+
+// Quote returns s as a single sway command argument. It is only quoted when
+// necessary. Backslashes and double quotes are escaped, since sway unescapes
+// both after splitting the arguments.
+//
+// sway replaces $name with the value of a variable defined with set before it
+// runs a command, and there is no escape that keeps it from doing so. Values
+// that contain a "$" followed by the name of a variable are changed.
+func Quote(s string) string {
+	if !needsQuote(s) {
+		return s
+	}
+
+	return `"` + quoteReplacer.Replace(s) + `"`
+}
+
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// shellQuote quotes s for sh. A single quote inside of s is written by closing
+// the quoted string, adding it in double quotes and reopening the string. It
+// can't be escaped with a backslash, since sway would treat the backslash as
+// escaping the closing quote.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			strings.ContainsRune("@%+=:./-_", r))
+	}) < 0 {
+		return s
+	}
+
+	return "'" + strings.Replace(s, "'", `'"'"'`, -1) + "'"
+}
+
+// execArgs returns argv as the argument of exec. sway passes it to sh -c after
+// removing the backslashes that come before quotes, so only those backslashes
+// are escaped.
+func execArgs(argv []string) string {
+	s := make([]string, len(argv))
+	for i, arg := range argv {
+		s[i] = shellQuote(arg)
+	}
+	return strings.NewReplacer(`\"`, `\\"`, `\'`, `\\'`).Replace(strings.Join(s, " "))
+}
@@ -0,0 +1,121 @@
+package command_test
+
+import (
+	"testing"
+
+	"github.com/joshuarubin/go-sway/command"
+)
+
+func TestCommandString(t *testing.T) {
+	for _, tc := range []struct {
+		cmd  command.Command
+		want string
+	}{
+		{command.FocusDirection(command.Left), "focus left"},
+		{command.Workspace("3:code"), "workspace 3:code"},
+		{command.Workspace("my work"), `workspace "my work"`},
+		{command.Workspace(`a"; exec rm -rf ~`), `workspace "a\"; exec rm -rf ~"`},
+		{command.Workspace(`back\slash`), `workspace "back\\slash"`},
+		{command.Workspace(`x\"; exec evil #`), `workspace "x\\\"; exec evil #"`},
+		{command.Workspace(`foo\`), `workspace "foo\\"`},
+		{command.Workspace("a;b"), `workspace "a;b"`},
+		{command.Workspace("a,b"), `workspace "a,b"`},
+		{command.Workspace("$mod"), "workspace $mod"},
+		{command.Workspace(""), `workspace ""`},
+		{command.MoveToWorkspaceNumber(4), "move container to workspace number 4"},
+		{command.RenameWorkspace("", "1: web"), `rename workspace to "1: web"`},
+		{command.Mark("x,y", command.MarkAdd, command.MarkToggle), `mark --add --toggle "x,y"`},
+		{command.ResizeSet(command.Size{Value: 50, Unit: command.Ppt}, command.Size{}), "resize set width 50 ppt"},
+		{command.Fullscreen(command.Toggle, true), "fullscreen toggle global"},
+		{command.Move(command.Up, 10), "move up 10 px"},
+		{command.Output("HDMI-A-1", "mode", "1920x1080@60Hz"), "output HDMI-A-1 mode 1920x1080@60Hz"},
+		{command.Input("type:touchpad", "tap", "enabled"), "input type:touchpad tap enabled"},
+		{command.Kill().With(command.ByAppID(command.Literal("org.gnome.Nautilus"))), `[app_id="^org\.gnome\.Nautilus$"] kill`},
+		{command.Kill().With(command.ByAppID(`^org\.gnome\..*$`)), `[app_id="^org\.gnome\..*$"] kill`},
+		{command.Focus().With(command.ByTitle(`say "hi"`), command.Floating()), `[title="say \"hi\"" floating] focus`},
+		{command.Focus().With(command.ByConID(42)), `[con_id="42"] focus`},
+		{command.Kill().With(command.ByTitle(`x\"; exec evil #`)), `[title="x\\"; exec evil #"] kill`},
+		{command.Kill().With(command.ByTitle(`foo\\`)), `[title="foo\\(?:)"] kill`},
+		{command.Kill().With(command.ByTitle("a;b,c]")), `[title="a;b,c]"] kill`},
+		{command.Exec("notify-send", "hello world"), "exec notify-send 'hello world'"},
+		{command.Exec("echo", "it's"), `exec echo 'it'"'"'s'`},
+		{command.Exec("printf", `a\n;b`), `exec printf 'a\n;b'`},
+		{command.ExecShell("a; b, c"), "exec sh -c 'a; b, c'"},
+	} {
+		if got := tc.cmd.String(); got != tc.want {
+			t.Errorf("got  %s\nwant %s", got, tc.want)
+		}
+	}
+
+	// values never end a command early, however they are quoted
+	for _, cmd := range []command.Command{
+		command.Workspace(`x\"; exec evil #`),
+		command.Workspace(`foo\`),
+		command.Workspace(`a;b,c`),
+		command.Kill().With(command.ByTitle(`x\"; exec evil #`)),
+		command.Kill().With(command.ByTitle(`foo\`)),
+		command.Kill().With(command.ByTitle(`a;b,c]`)),
+	} {
+		s := cmd.String()
+		if err := command.CheckTerminated(s); err != nil {
+			t.Errorf("%s: %v", s, err)
+		}
+
+		if got := command.SplitCommands(s + "; nop"); len(got) != 2 || got[0] != s {
+			t.Errorf("%s: split into %q", s, got)
+		}
+	}
+
+	got := command.Join(command.Workspace("1"), command.SetLayout(command.LayoutTabbed))
+	if want := "workspace 1; layout tabbed"; got != want {
+		t.Errorf("Join: got %q, want %q", got, want)
+	}
+}
+
+func TestSplitCommands(t *testing.T) {
+	for in, want := range map[string][]string{
+		"":                              nil,
+		"focus left":                    {"focus left"},
+		"workspace 1; layout tabbed;":   {"workspace 1", "layout tabbed"},
+		`[app_id="a;b"] focus, kill`:    {`[app_id="a;b"] focus`, "kill"},
+		`workspace "x\";y"; kill`:       {`workspace "x\";y"`, "kill"},
+		`exec sh -c 'a; b' ; nop`:       {`exec sh -c 'a; b'`, "nop"},
+		`exec echo 'it'"'"'s; ok', nop`: {`exec echo 'it'"'"'s; ok'`, "nop"},
+		`mark a\;b`:                     {`mark a\;b`},
+		` ; ,focus`:                     {"focus"},
+		`[title="a;b"] kill`:            {`[title="a;b"] kill`},
+		`[title=a;b] kill; [con_id=1] focus, kill`: {`[title=a;b] kill`, "[con_id=1] focus", "kill"},
+		`[title="a]"] kill`:                        {`[title="a]"] kill`},
+		`focus, [a;b`:                              {"focus", "[a", "b"},
+	} {
+		got := command.SplitCommands(in)
+		if len(got) != len(want) {
+			t.Errorf("%q: got %q, want %q", in, got, want)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("%q: got %q, want %q", in, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestCheckTerminated(t *testing.T) {
+	for in, ok := range map[string]bool{
+		"focus left":              true,
+		`[title="a;b"] kill`:      true,
+		`exec echo 'it'"'"'s'`:    true,
+		`workspace "unterminated`: false,
+		`exec echo 'unterminated`: false,
+		`[title="a"`:              false,
+		`mark a\`:                 false,
+		`focus, [a`:               true,
+	} {
+		if err := command.CheckTerminated(in); (err == nil) != ok {
+			t.Errorf("%q: got error %v, want ok %v", in, err, ok)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package command
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A Criterion limits which containers a command applies to
+type Criterion struct {
+	// Name is the criterion name, e.g. "app_id" or "con_mark"
+	Name string
+
+	// Value is the unquoted value of the criterion. Most criteria treat it as a
+	// PCRE regular expression. Some criteria, like "floating", have no value.
+	Value string
+}
+
+// String returns the criterion as it is written inside of square brackets.
+// sway only removes a backslash that comes before a double quote in criteria
+// values, so other backslashes, such as those in regular expressions, are
+// written as they are.
+//
+// sway ends a criteria value at the first double quote that doesn't follow a
+// backslash, so a value can't end with a backslash. An empty group is added to
+// such values, which doesn't change what they match as a regular expression.
+func (c Criterion) String() string {
+	if c.Value == "" && !criteriaWithValue[c.Name] {
+		return c.Name
+	}
+
+	value := strings.Replace(c.Value, `"`, `\"`, -1)
+	if strings.HasSuffix(value, `\`) {
+		value += "(?:)"
+	}
+
+	return c.Name + `="` + value + `"`
+}
+
+// criteriaWithValue lists the criteria that always take a value
+var criteriaWithValue = map[string]bool{
+	"app_id":         true,
+	"class":          true,
+	"con_id":         true,
+	"con_mark":       true,
+	"id":             true,
+	"instance":       true,
+	"pid":            true,
+	"sandbox_app_id": true,
+	"sandbox_engine": true,
+	"shell":          true,
+	"tag":            true,
+	"title":          true,
+	"urgent":         true,
+	"window_role":    true,
+	"window_type":    true,
+	"workspace":      true,
+}
+
+// Criteria is a set of criteria that all have to match
+type Criteria []Criterion
+
+// String returns the criteria in square brackets, or an empty string if there
+// are none
+func (c Criteria) String() string {
+	if len(c) == 0 {
+		return ""
+	}
+
+	s := make([]string, len(c))
+	for i, criterion := range c {
+		s[i] = criterion.String()
+	}
+
+	return "[" + strings.Join(s, " ") + "]"
+}
+
+// Literal returns a regular expression that only matches s exactly, for use
+// with criteria that take a regular expression
+func Literal(s string) string {
+	return "^" + regexp.QuoteMeta(s) + "$"
+}
+
+// ByAppID matches the app_id of xdg-shell views against a regular expression
+func ByAppID(regex string) Criterion {
+	return Criterion{Name: "app_id", Value: regex}
+}
+
+// ByClass matches the class of xwayland views against a regular expression
+func ByClass(regex string) Criterion {
+	return Criterion{Name: "class", Value: regex}
+}
+
+// ByInstance matches the instance of xwayland views against a regular
+// expression
+func ByInstance(regex string) Criterion {
+	return Criterion{Name: "instance", Value: regex}
+}
+
+// ByTitle matches the window title against a regular expression
+func ByTitle(regex string) Criterion {
+	return Criterion{Name: "title", Value: regex}
+}
+
+// ByConID matches the container with the given ID, i.e. Node.ID
+func ByConID(id int64) Criterion {
+	return Criterion{Name: "con_id", Value: strconv.FormatInt(id, 10)}
+}
+
+// ByMark matches containers with a mark matching a regular expression
+func ByMark(regex string) Criterion {
+	return Criterion{Name: "con_mark", Value: regex}
+}
+
+// ByWorkspace matches containers on workspaces whose name matches a regular
+// expression
+func ByWorkspace(regex string) Criterion {
+	return Criterion{Name: "workspace", Value: regex}
+}
+
+// ByPID matches views owned by the process with the given PID
+func ByPID(pid uint32) Criterion {
+	return Criterion{Name: "pid", Value: strconv.FormatUint(uint64(pid), 10)}
+}
+
+// ByShell matches views using a shell, e.g. "xdg_shell" or "xwayland", that
+// matches a regular expression
+func ByShell(regex string) Criterion {
+	return Criterion{Name: "shell", Value: regex}
+}
+
+// ByX11ID matches the xwayland view with the given X11 window ID
+func ByX11ID(id int64) Criterion {
+	return Criterion{Name: "id", Value: strconv.FormatInt(id, 10)}
+}
+
+// Floating matches floating views
+func Floating() Criterion {
+	return Criterion{Name: "floating"}
+}
+
+// Tiling matches tiling views
+func Tiling() Criterion {
+	return Criterion{Name: "tiling"}
+}
+
+// Urgent matches urgent views. The value can be "first", "last", "latest",
+// "newest", "oldest", or "recent".
+func Urgent(which string) Criterion {
+	return Criterion{Name: "urgent", Value: which}
+}
+
+// All matches all views
+func All() Criterion {
+	return Criterion{Name: "all"}
+}
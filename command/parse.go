@@ -0,0 +1,329 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse parses a command string, as passed to Client.RunCommand or used in a
+// binding, into the commands sway would run. Commands chained with commas share
+// the criteria of the first command in the chain, so Parse copies the criteria
+// to each of them. Commands are not validated, use Validate for that.
+//
+// Parse and String are inverses: parsing the String of a Command returns an
+// equal Command. The only exception is a criteria value that ends with a
+// backslash, which String has to change as described for Criterion.
+func Parse(s string) (List, error) {
+	var ret List
+
+	for _, chain := range splitUnquoted(s, ";") {
+		chain = strings.TrimLeft(chain, whitespace)
+
+		var criteria Criteria
+		if strings.HasPrefix(chain, "[") {
+			var err error
+			if criteria, chain, err = parseCriteria(chain); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, part := range splitUnquoted(chain, ",") {
+			part = strings.Trim(part, whitespace)
+			if part == "" {
+				continue
+			}
+
+			c, err := parseCommand(part)
+			if err != nil {
+				return nil, err
+			}
+
+			c.Criteria = criteria
+			ret = append(ret, c)
+		}
+	}
+
+	return ret, nil
+}
+
+// ParseCommand parses a command string that contains exactly one command
+func ParseCommand(s string) (Command, error) {
+	l, err := Parse(s)
+	if err != nil {
+		return Command{}, err
+	}
+
+	if len(l) != 1 {
+		return Command{}, fmt.Errorf("expected 1 command in %q, got %d", s, len(l))
+	}
+
+	return l[0], nil
+}
+
+const whitespace = " \t\r\n"
+
+func parseCommand(s string) (Command, error) {
+	if strings.HasPrefix(s, "[") {
+		return Command{}, fmt.Errorf("%q: criteria can only be used at the start of a command chain", s)
+	}
+
+	name, rest := s, ""
+	if i := strings.IndexAny(s, whitespace); i >= 0 {
+		name, rest = s[:i], strings.Trim(s[i:], whitespace)
+	}
+
+	c := Command{Name: name}
+
+	if isExec(name) {
+		if rest != "" {
+			c.Args = parseExec(rest)
+		}
+		return c, nil
+	}
+
+	words, err := splitWords(rest)
+	if err != nil {
+		return Command{}, fmt.Errorf("%q: %s", s, err)
+	}
+
+	for _, w := range words {
+		c.Args = append(c.Args, unquote(w))
+	}
+
+	return c, nil
+}
+
+// splitWords splits s at unquoted whitespace
+func splitWords(s string) ([]string, error) {
+	var (
+		ret              []string
+		inString, inChar bool
+		escaped          bool
+		word             strings.Builder
+		inWord           bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+
+		if !inString && !inChar && !escaped && strings.IndexByte(whitespace, ch) >= 0 {
+			if inWord {
+				ret = append(ret, word.String())
+				word.Reset()
+				inWord = false
+			}
+			continue
+		}
+
+		switch {
+		case ch == '"' && !inChar && !escaped:
+			inString = !inString
+		case ch == '\'' && !inString && !escaped:
+			inChar = !inChar
+		}
+
+		if ch == '\\' {
+			escaped = !escaped
+		} else {
+			escaped = false
+		}
+
+		word.WriteByte(ch)
+		inWord = true
+	}
+
+	if inString || inChar {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+
+	if inWord {
+		ret = append(ret, word.String())
+	}
+
+	return ret, nil
+}
+
+// unquote removes the unescaped quotes from s and the backslashes that escape
+// quotes or backslashes. Other backslashes are kept, like sway does for command
+// arguments.
+func unquote(s string) string {
+	var (
+		b                strings.Builder
+		inString, inChar bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+
+		switch {
+		case ch == '\\' && i+1 < len(s) && (isQuote(s[i+1]) || s[i+1] == '\\'):
+			i++
+			b.WriteByte(s[i])
+			continue
+		case ch == '"' && !inChar:
+			inString = !inString
+			continue
+		case ch == '\'' && !inString:
+			inChar = !inChar
+			continue
+		}
+
+		b.WriteByte(ch)
+	}
+
+	return b.String()
+}
+
+// unescape removes the backslashes that escape quotes in the arguments of exec
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isQuote(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isQuote(ch byte) bool {
+	return ch == '"' || ch == '\''
+}
+
+func parseCriteria(s string) (Criteria, string, error) {
+	var (
+		ret Criteria
+		i   = 1
+	)
+
+	for {
+		for i < len(s) && strings.IndexByte(whitespace, s[i]) >= 0 {
+			i++
+		}
+
+		if i == len(s) {
+			return nil, "", fmt.Errorf("%q: unterminated criteria", s)
+		}
+
+		if s[i] == ']' {
+			return ret, s[i+1:], nil
+		}
+
+		start := i
+		for i < len(s) && s[i] != '=' && s[i] != ']' && strings.IndexByte(whitespace, s[i]) < 0 {
+			i++
+		}
+
+		c := Criterion{Name: s[start:i]}
+
+		if i < len(s) && s[i] == '=' {
+			i++
+
+			if i < len(s) && s[i] == '"' {
+				var b strings.Builder
+				for i++; i < len(s) && s[i] != '"'; i++ {
+					if s[i] == '\\' && i+1 < len(s) && s[i+1] == '"' {
+						i++
+					}
+					b.WriteByte(s[i])
+				}
+
+				if i == len(s) {
+					return nil, "", fmt.Errorf("%q: unterminated criteria value", s)
+				}
+
+				i++
+				c.Value = b.String()
+			} else {
+				start = i
+				for i < len(s) && s[i] != ']' && strings.IndexByte(whitespace, s[i]) < 0 {
+					i++
+				}
+				c.Value = s[start:i]
+			}
+		}
+
+		if c.Name == "" {
+			return nil, "", fmt.Errorf("%q: empty criterion", s)
+		}
+
+		ret = append(ret, c)
+	}
+}
+
+// parseExec returns the arguments of exec as the argv of the program to run.
+// If the shell would do more than split words and remove quotes, e.g. for
+// pipes or variables, the argv runs the whole script with sh -c instead.
+func parseExec(s string) []string {
+	script := unescape(s)
+
+	if argv, ok := shellWords(script); ok {
+		return argv
+	}
+
+	return []string{"sh", "-c", script}
+}
+
+// shellWords splits s like sh would, as long as it only contains words and
+// quotes. It returns false if s uses any other shell syntax.
+func shellWords(s string) ([]string, bool) {
+	var (
+		ret    []string
+		word   strings.Builder
+		inWord bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+
+		switch {
+		case strings.IndexByte(whitespace, ch) >= 0:
+			if inWord {
+				ret = append(ret, word.String())
+				word.Reset()
+				inWord = false
+			}
+			continue
+		case ch == '\'':
+			j := strings.IndexByte(s[i+1:], '\'')
+			if j < 0 {
+				return nil, false
+			}
+			word.WriteString(s[i+1 : i+1+j])
+			i += j + 1
+		case ch == '"':
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if strings.IndexByte("$`", s[i]) >= 0 {
+					return nil, false
+				}
+
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\", s[i+1]) >= 0 {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+
+			if i == len(s) {
+				return nil, false
+			}
+		case ch == '\\':
+			if i+1 == len(s) {
+				return nil, false
+			}
+			i++
+			word.WriteByte(s[i])
+		case strings.IndexByte("|&;<>()$`*?[]#~{}!", ch) >= 0:
+			return nil, false
+		default:
+			word.WriteByte(ch)
+		}
+
+		inWord = true
+	}
+
+	if inWord {
+		ret = append(ret, word.String())
+	}
+
+	return ret, len(ret) > 0
+}
@@ -0,0 +1,151 @@
+package command_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joshuarubin/go-sway/command"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want command.List
+	}{
+		{"", nil},
+		{"focus left", command.List{command.FocusDirection(command.Left)}},
+		{`workspace "my work"; layout tabbed`, command.List{
+			command.Workspace("my work"),
+			command.SetLayout(command.LayoutTabbed),
+		}},
+		{`[app_id="^foot$" floating] move scratchpad, scratchpad show; kill`, command.List{
+			command.MoveToScratchpad().With(command.ByAppID("^foot$"), command.Floating()),
+			command.ScratchpadShow().With(command.ByAppID("^foot$"), command.Floating()),
+			command.Kill(),
+		}},
+		{`[con_mark=x title="a \"b\" \c"] focus`, command.List{
+			command.Focus().With(command.ByMark("x"), command.ByTitle(`a "b" \c`)),
+		}},
+		{`mark --add 'it"s'`, command.List{command.Mark(`it"s`, command.MarkAdd)}},
+		{`exec notify-send "hello world"`, command.List{command.Exec("notify-send", "hello world")}},
+		{`exec grim -g "$(slurp)" - | wl-copy`, command.List{
+			command.ExecShell(`grim -g "$(slurp)" - | wl-copy`),
+		}},
+	} {
+		got, err := command.Parse(tc.in)
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q:\ngot  %#v\nwant %#v", tc.in, got, tc.want)
+		}
+	}
+
+	for _, in := range []string{
+		`[app_id="foo" focus`,
+		`focus, [app_id=foo] kill`,
+		`workspace "unterminated`,
+	} {
+		if _, err := command.Parse(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	for _, cmd := range []command.Command{
+		command.Workspace(`a"; exec rm -rf ~`),
+		command.Workspace(`back\slash`),
+		command.Workspace(`x\"; exec evil #`),
+		command.Workspace(`foo\`),
+		command.Workspace(`a\\"b\`),
+		command.Workspace("a;b"),
+		command.Workspace("a,b"),
+		command.Kill().With(command.ByTitle(`x\"; exec evil #`)),
+		command.Kill().With(command.ByTitle("a;b,c]")),
+		command.Workspace(""),
+		command.RenameWorkspace("1", "1: web"),
+		command.Mark("x,y", command.MarkAdd, command.MarkToggle),
+		command.ResizeSet(command.Size{Value: 50, Unit: command.Ppt}, command.Size{Value: 300, Unit: command.Px}),
+		command.Kill().With(command.ByAppID(command.Literal("org.gnome.Nautilus"))),
+		command.Focus().With(command.ByTitle(`say "hi" \o/`), command.Urgent("latest")),
+		command.Exec("echo", "it's"),
+		command.Exec("printf", `a\n;b`),
+		command.Exec("echo", `a\"b\'c`),
+		command.Focus().With(command.ByAppID(`^org\.gnome\..*\\"$`)),
+		command.Exec("notify-send", `"quoted" $HOME`),
+		command.ExecShell("a; b, c"),
+		command.Nop("some comment"),
+	} {
+		s := cmd.String()
+
+		got, err := command.ParseCommand(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, cmd) {
+			t.Errorf("%s:\ngot  %#v\nwant %#v", s, got, cmd)
+		}
+
+		if err := got.Validate(); err != nil {
+			t.Errorf("%s: %v", s, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for in, ok := range map[string]bool{
+		"focus left":                   true,
+		"focus output HDMI-A-1":        true,
+		"focus sideways":               false,
+		"move left 10 px":              true,
+		"move container to workspace":  true,
+		"move nowhere":                 false,
+		"layout toggle split tabbed":   true,
+		"layout grid":                  false,
+		"floating toggle":              true,
+		"fullscreen disable global":    true,
+		"kill now":                     false,
+		"resize grow width 10 px":      true,
+		"resize grow depth 10 px":      false,
+		"rename workspace 1 to 2":      true,
+		"rename workspace 1 2":         false,
+		"output * dpms off":            true,
+		"create_output":                true,
+		"bindsym Mod4+Return exec foo": true,
+		"unbindsym Mod4+Return":        true,
+		"set $mod Mod4":                true,
+	} {
+		cmd, err := command.ParseCommand(in)
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+
+		if err := cmd.Validate(); (err == nil) != ok {
+			t.Errorf("%q: got error %v, want ok %v", in, err, ok)
+		}
+	}
+}
+
+func TestValidateUnverified(t *testing.T) {
+	l, err := command.Parse("frobnicate; focus sideways")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := l[0].Validate().(*command.UnverifiedError); !ok {
+		t.Errorf("unknown command: got %v, want an *UnverifiedError", l[0].Validate())
+	}
+
+	// an invalid command is reported before an unverified one
+	if err = l.Validate(); err == nil {
+		t.Error("expected an error")
+	} else if _, ok := err.(*command.UnverifiedError); ok {
+		t.Errorf("got %v, want the invalid focus command", err)
+	}
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SplitCommands splits a command string into the individual commands sway
+// runs, in the same way sway does. Commands are separated by semicolons and
+// commas that aren't quoted or part of criteria. Commands following a comma
+// share the criteria of the preceding command, but SplitCommands does not copy
+// them. Empty commands are dropped since sway ignores them.
+func SplitCommands(s string) []string {
+	var ret []string
+	for _, part := range splitUnquoted(s, ";,") {
+		if part = strings.TrimSpace(part); part != "" {
+			ret = append(ret, part)
+		}
+	}
+	return ret
+}
+
+// CheckTerminated returns an error if s ends inside of a quote or criteria, or
+// with a backslash. Joining such a command string with another one would make
+// sway read the second as part of the first.
+func CheckTerminated(s string) error {
+	_, err := split(s, ";,")
+	return err
+}
+
+// splitUnquoted splits s at each of the delimiter bytes that isn't quoted,
+// escaped or part of criteria
+func splitUnquoted(s, delims string) []string {
+	ret, _ := split(s, delims)
+	return ret
+}
+
+// split follows sway's argsep: double and single quotes are tracked separately
+// and a backslash escapes the next byte, even inside of quotes. Like sway,
+// criteria are only recognized at the start of s and after a semicolon, and
+// they end at the first "]" that isn't quoted. Inside of criteria, only double
+// quotes are recognized and one that follows a backslash doesn't end a value.
+func split(s, delims string) ([]string, error) {
+	var (
+		ret              []string
+		inString, inChar bool
+		inCriteria       bool
+		escaped          bool
+		atStart          = true
+		start            int
+	)
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+
+		if atStart && !strings.ContainsRune(whitespace, rune(ch)) {
+			atStart = false
+			inCriteria = ch == '['
+		}
+
+		if inCriteria {
+			switch {
+			case ch == '"' && (i == 0 || s[i-1] != '\\'):
+				inString = !inString
+			case ch == ']' && !inString:
+				inCriteria = false
+			}
+			continue
+		}
+
+		switch {
+		case ch == '"' && !inChar && !escaped:
+			inString = !inString
+		case ch == '\'' && !inString && !escaped:
+			inChar = !inChar
+		case ch == '\\':
+			escaped = !escaped
+		case inString || inChar || escaped:
+		case strings.IndexByte(delims, ch) >= 0:
+			ret = append(ret, s[start:i])
+			start = i + 1
+			atStart = ch == ';'
+		}
+
+		if ch != '\\' {
+			escaped = false
+		}
+	}
+
+	ret = append(ret, s[start:])
+
+	switch {
+	case inCriteria:
+		return ret, fmt.Errorf("%q: unterminated criteria", s)
+	case inString, inChar:
+		return ret, fmt.Errorf("%q: unterminated quote", s)
+	case escaped:
+		return ret, fmt.Errorf("%q: trailing backslash", s)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,310 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UnverifiedError is returned by Validate for commands it doesn't know. They
+// may still be valid, e.g. commands added by a newer version of sway.
+type UnverifiedError struct {
+	Name string
+}
+
+func (e *UnverifiedError) Error() string {
+	return e.Name + ": unknown command, not verified"
+}
+
+// Validate checks that c is a command sway knows and, for the common commands,
+// that its arguments have the expected form. Settings passed to commands such
+// as output and input are not checked. Commands that aren't known return an
+// *UnverifiedError.
+func (c Command) Validate() error {
+	check, ok := validators[c.Name]
+	if !ok {
+		return &UnverifiedError{Name: c.Name}
+	}
+
+	if check == nil {
+		return nil
+	}
+
+	if err := check(c.Args); err != nil {
+		return fmt.Errorf("%s: %s", c.Name, err)
+	}
+
+	return nil
+}
+
+// Validate validates each of the commands in l. It returns the first invalid
+// command or, if there is none, the first unverified one.
+func (l List) Validate() error {
+	var unverified error
+	for _, c := range l {
+		err := c.Validate()
+		if _, ok := err.(*UnverifiedError); ok {
+			if unverified == nil {
+				unverified = err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return unverified
+}
+
+type validator func(args []string) error
+
+// validators holds the commands that can be run at runtime. Commands with a nil
+// validator take arguments that aren't checked.
+var validators = map[string]validator{
+	"allow_tearing":                 oneOf(states...),
+	"assign":                        minArgs(2),
+	"bar":                           minArgs(1),
+	"bindcode":                      minArgs(2),
+	"bindgesture":                   minArgs(2),
+	"bindswitch":                    minArgs(2),
+	"bindsym":                       minArgs(2),
+	"border":                        minArgs(1),
+	"client.background":             minArgs(1),
+	"client.focused":                minArgs(1),
+	"client.focused_inactive":       minArgs(1),
+	"client.focused_tab_title":      minArgs(1),
+	"client.placeholder":            minArgs(1),
+	"client.unfocused":              minArgs(1),
+	"client.urgent":                 minArgs(1),
+	"create_output":                 noArgs,
+	"default_border":                minArgs(1),
+	"default_floating_border":       minArgs(1),
+	"default_orientation":           oneOf("horizontal", "vertical", "auto"),
+	"exec":                          minArgs(1),
+	"exec_always":                   minArgs(1),
+	"exit":                          noArgs,
+	"floating":                      oneOf(states...),
+	"floating_maximum_size":         nil,
+	"floating_minimum_size":         nil,
+	"floating_modifier":             minArgs(1),
+	"focus":                         validateFocus,
+	"focus_follows_mouse":           minArgs(1),
+	"focus_on_window_activation":    minArgs(1),
+	"focus_wrapping":                minArgs(1),
+	"font":                          minArgs(1),
+	"for_window":                    minArgs(2),
+	"force_display_urgency_hint":    minArgs(1),
+	"force_focus_wrapping":          minArgs(1),
+	"fullscreen":                    validateFullscreen,
+	"gaps":                          minArgs(2),
+	"hide_edge_borders":             minArgs(1),
+	"include":                       minArgs(1),
+	"inhibit_idle":                  oneOf("focus", "fullscreen", "open", "none", "visible"),
+	"input":                         minArgs(2),
+	"kill":                          noArgs,
+	"layout":                        validateLayout,
+	"mark":                          minArgs(1),
+	"max_render_time":               minArgs(1),
+	"mode":                          minArgs(1),
+	"mouse_warping":                 minArgs(1),
+	"move":                          validateMove,
+	"no_focus":                      minArgs(1),
+	"nop":                           nil,
+	"opacity":                       minArgs(1),
+	"output":                        minArgs(1),
+	"popup_during_fullscreen":       minArgs(1),
+	"reload":                        noArgs,
+	"rename":                        validateRename,
+	"resize":                        validateResize,
+	"scratchpad":                    oneOf("show"),
+	"seat":                          minArgs(1),
+	"set":                           minArgs(2),
+	"shortcuts_inhibitor":           oneOf(states...),
+	"show_marks":                    minArgs(1),
+	"smart_borders":                 minArgs(1),
+	"smart_gaps":                    minArgs(1),
+	"split":                         oneOf("vertical", "v", "horizontal", "h", "toggle", "t", "none", "n"),
+	"splith":                        noArgs,
+	"splitt":                        noArgs,
+	"splitv":                        noArgs,
+	"sticky":                        oneOf(states...),
+	"swap":                          minArgs(4),
+	"swaybg_command":                minArgs(1),
+	"swaynag_command":               minArgs(1),
+	"tiling_drag":                   minArgs(1),
+	"tiling_drag_threshold":         minArgs(1),
+	"title_align":                   oneOf("left", "center", "right"),
+	"title_format":                  minArgs(1),
+	"titlebar_border_thickness":     minArgs(1),
+	"titlebar_padding":              minArgs(1),
+	"unbindcode":                    minArgs(1),
+	"unbindgesture":                 minArgs(1),
+	"unbindswitch":                  minArgs(1),
+	"unbindsym":                     minArgs(1),
+	"unmark":                        nil,
+	"urgent":                        oneOf(append([]string{"allow", "deny"}, states...)...),
+	"workspace":                     minArgs(1),
+	"workspace_auto_back_and_forth": minArgs(1),
+	"workspace_layout":              minArgs(1),
+	"xwayland":                      minArgs(1),
+}
+
+var (
+	states     = []string{string(Enable), string(Disable), string(Toggle), "yes", "no", "true", "false", "on", "off"}
+	directions = []string{string(Left), string(Right), string(Up), string(Down)}
+)
+
+func noArgs(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("takes no arguments")
+	}
+	return nil
+}
+
+func minArgs(n int) validator {
+	return func(args []string) error {
+		if len(args) < n {
+			return fmt.Errorf("expected at least %d argument(s), got %d", n, len(args))
+		}
+		return nil
+	}
+}
+
+func oneOf(values ...string) validator {
+	return func(args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("expected one of %s", strings.Join(values, ", "))
+		}
+		if !isOneOf(args[0], values...) {
+			return fmt.Errorf("invalid argument %q, expected one of %s", args[0], strings.Join(values, ", "))
+		}
+		return nil
+	}
+}
+
+func isOneOf(s string, values ...string) bool {
+	for _, v := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func validateFocus(args []string) error {
+	switch {
+	case len(args) == 0:
+		return nil
+	case args[0] == "output":
+		return minArgs(2)(args)
+	case isOneOf(args[0], "prev", "next"):
+		if len(args) == 1 || len(args) == 2 && args[1] == "sibling" {
+			return nil
+		}
+		return fmt.Errorf("invalid arguments %q", strings.Join(args, " "))
+	case isOneOf(args[0], directions...):
+		if len(args) == 1 || len(args) == 2 && args[1] == "nowrap" {
+			return nil
+		}
+		return fmt.Errorf("invalid arguments %q", strings.Join(args, " "))
+	}
+
+	return oneOf("parent", "child", "floating", "tiling", "mode_toggle")(args)
+}
+
+func validateFullscreen(args []string) error {
+	switch len(args) {
+	case 0:
+		return nil
+	case 1:
+		if args[0] == "global" || isOneOf(args[0], states...) {
+			return nil
+		}
+	case 2:
+		if isOneOf(args[0], states...) && args[1] == "global" {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid arguments %q", strings.Join(args, " "))
+}
+
+func validateLayout(args []string) error {
+	layouts := []string{
+		string(LayoutDefault), string(LayoutSplitH), string(LayoutSplitV),
+		string(LayoutStacked), string(LayoutTabbed),
+	}
+
+	if len(args) > 0 && args[0] == "toggle" {
+		for _, arg := range args[1:] {
+			if !isOneOf(arg, append(layouts, "split", "all")...) {
+				return fmt.Errorf("invalid layout %q", arg)
+			}
+		}
+		return nil
+	}
+
+	return oneOf(layouts...)(args)
+}
+
+func validateMove(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected at least 1 argument(s), got 0")
+	}
+
+	if isOneOf(args[0], directions...) {
+		switch {
+		case len(args) == 1:
+			return nil
+		case len(args) <= 3 && isInt(args[1]) && (len(args) == 2 || isOneOf(args[2], "px", "ppt")):
+			return nil
+		}
+		return fmt.Errorf("invalid arguments %q", strings.Join(args, " "))
+	}
+
+	if isOneOf(args[0], "container", "window", "workspace", "output", "scratchpad",
+		"position", "absolute", "to", "--no-auto-back-and-forth") {
+		return nil
+	}
+
+	return fmt.Errorf("invalid argument %q", args[0])
+}
+
+func validateRename(args []string) error {
+	if len(args) < 3 || args[0] != "workspace" {
+		return fmt.Errorf("expected \"workspace [<old_name>] to <new_name>\"")
+	}
+
+	for _, arg := range args[1 : len(args)-1] {
+		if arg == "to" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("missing \"to\"")
+}
+
+func validateResize(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("expected at least 2 argument(s), got %d", len(args))
+	}
+
+	switch args[0] {
+	case "grow", "shrink":
+		if !isOneOf(args[1], "width", "height", "horizontal", "vertical", "up", "down", "left", "right") {
+			return fmt.Errorf("invalid dimension %q", args[1])
+		}
+		if len(args) > 2 && !isInt(args[2]) {
+			return fmt.Errorf("invalid amount %q", args[2])
+		}
+		return nil
+	case "set":
+		return nil
+	}
+
+	return fmt.Errorf("invalid argument %q", args[0])
+}
+
+func isInt(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
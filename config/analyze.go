@@ -0,0 +1,256 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	sway "github.com/joshuarubin/go-sway"
+	"github.com/joshuarubin/go-sway/command"
+)
+
+// FindingKind is the kind of problem reported by Analyze
+type FindingKind string
+
+const (
+	// FindingDuplicate is a binding that replaces an earlier binding for the
+	// same keys in the same mode
+	FindingDuplicate FindingKind = "duplicate"
+
+	// FindingShadowed is a binding for different keys than another binding
+	// that resolves to the same keycodes, because of bindcode or bindsym
+	// --to-code
+	FindingShadowed FindingKind = "shadowed"
+
+	// FindingNoExit is a mode that has no chain of bindings leading back to the
+	// default mode
+	FindingNoExit FindingKind = "no_exit"
+)
+
+// A Finding is a problem found by Analyze
+type Finding struct {
+	Kind FindingKind `json:"kind"`
+	Mode string      `json:"mode"`
+
+	// Pos is the location of the binding or mode the finding is about
+	Pos Pos `json:"pos"`
+
+	// Related holds the locations of other bindings involved, e.g. the binding
+	// that is replaced by a duplicate
+	Related []Pos `json:"related,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return f.Pos.String() + ": " + f.Message
+}
+
+// Analyze reports duplicate and shadowed bindings and modes that can't be
+// left in f. Keysyms of bindsym --to-code bindings are resolved with keycodes,
+// or with USKeycodes if it is nil. The findings are sorted by location.
+func Analyze(f *File, keycodes KeycodeFunc) []Finding {
+	if keycodes == nil {
+		keycodes = USKeycodes
+	}
+
+	bindings := Bindings(f)
+
+	ret := duplicates(bindings)
+	ret = append(ret, shadowed(bindings, keycodes)...)
+	ret = append(ret, noExit(f, bindings)...)
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		a, b := ret[i].Pos, ret[j].Pos
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return ret
+}
+
+// LoadFindings analyzes the config loaded by sway. See LoadInventory for how
+// includes are resolved.
+func LoadFindings(ctx context.Context, c sway.Client, keycodes KeycodeFunc, opts ...Option) ([]Finding, error) {
+	f, err := loadConfig(ctx, c, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return Analyze(f, keycodes), nil
+}
+
+func duplicates(bindings []Binding) []Finding {
+	var (
+		ret  []Finding
+		seen = map[string]Binding{}
+	)
+
+	for _, b := range bindings {
+		if strings.HasPrefix(b.Kind, "un") {
+			b.Kind = strings.TrimPrefix(b.Kind, "un")
+			delete(seen, b.id())
+			continue
+		}
+
+		id := b.id()
+		if prev, ok := seen[id]; ok {
+			ret = append(ret, Finding{
+				Kind:    FindingDuplicate,
+				Mode:    b.Mode,
+				Pos:     b.Pos,
+				Related: []Pos{prev.Pos},
+				Message: fmt.Sprintf("%s %s in mode %q replaces the binding at %s", b.Kind, b.Keys, b.Mode, prev.Pos),
+			})
+		}
+
+		seen[id] = b
+	}
+
+	return ret
+}
+
+// keycodeID returns the fields that identify b once its keys are translated to
+// keycodes, or false if b isn't matched by keycode
+func keycodeID(b Binding, keycodes KeycodeFunc) (string, bool) {
+	if b.Kind != "bindcode" && (b.Kind != "bindsym" || !b.ToCode) {
+		return "", false
+	}
+
+	codes := make([]int, len(b.Key))
+	for i, k := range b.Key {
+		var (
+			code int
+			err  error
+			ok   bool
+		)
+
+		if b.Kind == "bindcode" {
+			code, err = strconv.Atoi(k)
+			ok = err == nil
+		} else {
+			code, ok = keycodes(k)
+		}
+
+		if !ok {
+			return "", false
+		}
+
+		codes[i] = code
+	}
+
+	sort.Ints(codes)
+
+	return fmt.Sprintf("%s\x00%s\x00%v\x00%v", b.Mode, strings.Join(b.Modifiers, "+"), codes, b.Release), true
+}
+
+func shadowed(bindings []Binding, keycodes KeycodeFunc) []Finding {
+	var (
+		ret   []Finding
+		order []string
+		byID  = map[string][]Binding{}
+	)
+
+	for _, m := range NewInventory(bindings).Modes {
+		for _, b := range m.Bindings {
+			id, ok := keycodeID(b, keycodes)
+			if !ok {
+				continue
+			}
+
+			if _, ok := byID[id]; !ok {
+				order = append(order, id)
+			}
+
+			byID[id] = append(byID[id], b)
+		}
+	}
+
+	for _, id := range order {
+		list := byID[id]
+		if len(list) < 2 {
+			continue
+		}
+
+		// sway keeps the binding that is read last
+		last := list[len(list)-1]
+		for _, b := range list[:len(list)-1] {
+			ret = append(ret, Finding{
+				Kind:    FindingShadowed,
+				Mode:    b.Mode,
+				Pos:     b.Pos,
+				Related: []Pos{last.Pos},
+				Message: fmt.Sprintf("%s %s in mode %q uses the same keycodes as %s %s at %s", b.Kind, b.Keys, b.Mode, last.Kind, last.Keys, last.Pos),
+			})
+		}
+	}
+
+	return ret
+}
+
+func noExit(f *File, bindings []Binding) []Finding {
+	order, pos := modes(f)
+	next := map[string][]string{}
+
+	for _, m := range NewInventory(bindings).Modes {
+		for _, b := range m.Bindings {
+			cmds, err := command.Parse(b.Command)
+			if err != nil {
+				continue
+			}
+
+			for _, c := range cmds {
+				if c.Name == "mode" && len(c.Args) > 0 {
+					next[b.Mode] = append(next[b.Mode], c.Args[len(c.Args)-1])
+				}
+			}
+		}
+	}
+
+	var ret []Finding
+	for _, mode := range order {
+		if mode == DefaultMode || reaches(mode, DefaultMode, next) {
+			continue
+		}
+
+		ret = append(ret, Finding{
+			Kind:    FindingNoExit,
+			Mode:    mode,
+			Pos:     pos[mode],
+			Message: fmt.Sprintf("mode %q has no bindings that lead back to the %q mode", mode, DefaultMode),
+		})
+	}
+
+	return ret
+}
+
+// reaches returns true if there is a path from mode "from" to mode "to"
+func reaches(from, to string, next map[string][]string) bool {
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		mode := queue[0]
+		queue = queue[1:]
+
+		for _, n := range next[mode] {
+			if n == to {
+				return true
+			}
+
+			if !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,136 @@
+package config_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/joshuarubin/go-sway/config"
+)
+
+const analyzeConfig = `set $mod Mod4
+bindsym $mod+Return exec foot
+bindsym $mod+Return exec alacritty
+bindsym --release $mod+Return exec foot
+bindsym --to-code $mod+q kill
+bindcode $mod+24 exec true
+bindsym --to-code $mod+Shift+semicolon exec a
+bindsym --to-code $mod+Shift+colon exec b
+bindsym $mod+r mode resize
+bindsym $mod+x mode trap
+
+mode resize {
+    bindsym Escape mode menu
+}
+
+mode menu {
+    bindsym Return mode default
+}
+
+mode trap {
+    bindsym a exec true
+}
+
+mode "dead end" {
+}
+`
+
+func TestAnalyze(t *testing.T) {
+	f, err := config.Parse("cfg", analyzeConfig, config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, finding := range config.Analyze(f, nil) {
+		got = append(got, string(finding.Kind)+" "+finding.String())
+	}
+
+	want := []string{
+		`duplicate cfg:3:1: bindsym Mod4+Return in mode "default" replaces the binding at cfg:2:1`,
+		`shadowed cfg:5:1: bindsym Mod4+q in mode "default" uses the same keycodes as bindcode Mod4+24 at cfg:6:1`,
+		`shadowed cfg:7:1: bindsym Mod4+Shift+semicolon in mode "default" uses the same keycodes as bindsym Mod4+Shift+colon at cfg:8:1`,
+		`no_exit cfg:20:1: mode "trap" has no bindings that lead back to the "default" mode`,
+		`no_exit cfg:24:1: mode "dead end" has no bindings that lead back to the "default" mode`,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got findings:\n%q\nwant:\n%q", got, want)
+	}
+
+	findings, err := config.LoadFindings(context.Background(), &fakeClient{config: analyzeConfig}, nil, config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(findings) != len(want) {
+		t.Errorf("got %d findings from the live config, want %d", len(findings), len(want))
+	}
+
+	if r := findings[0].Related; len(r) != 1 || r[0].Line != 2 {
+		t.Errorf("unexpected related locations %v", r)
+	}
+}
+
+func TestUSKeycodes(t *testing.T) {
+	for sym, want := range map[string]int{
+		"q": 24, "Q": 24, "Return": 36, "1": 10, "exclam": 10,
+		"slash": 61, "question": 61, "F12": 96, "XF86AudioMute": 121,
+	} {
+		if got, ok := config.USKeycodes(sym); !ok || got != want {
+			t.Errorf("%s: got %d, %v, want %d", sym, got, ok, want)
+		}
+	}
+
+	if _, ok := config.USKeycodes("NoSuchKey"); ok {
+		t.Error("expected unknown keysym to fail")
+	}
+}
+
+func TestAnalyzeDistinctFlags(t *testing.T) {
+	f, err := config.Parse("cfg", `bindsym XF86AudioMute exec a
+bindsym --locked XF86AudioMute exec b
+bindsym --inhibited XF86AudioMute exec c
+bindsym button4 exec d
+bindsym --whole-window button4 exec e
+bindsym --border button4 exec f
+bindsym --exclude-titlebar button4 exec g
+`, config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sway keeps all of these bindings, so none of them is a duplicate
+	if findings := config.Analyze(f, nil); len(findings) != 0 {
+		t.Errorf("unexpected findings %v", findings)
+	}
+}
+
+func TestLoadFindingsInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sway-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "keys.conf"), []byte("bindsym Mod4+q exec b\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &fakeClient{
+		config: "bindsym Mod4+q exec a\ninclude keys.conf\n",
+		loaded: filepath.Join(dir, "config"),
+	}
+
+	findings, err := config.LoadFindings(context.Background(), c, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(findings) != 1 || findings[0].Kind != config.FindingDuplicate {
+		t.Errorf("unexpected findings %v", findings)
+	}
+}
@@ -0,0 +1,344 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+// DefaultMode is the name of the binding mode sway starts in
+const DefaultMode = "default"
+
+// A Binding is a bindsym, bindcode, bindswitch or bindgesture statement with
+// its variables expanded
+type Binding struct {
+	// Mode is the binding mode the binding belongs to
+	Mode string `json:"mode"`
+
+	// Kind is the statement that created the binding, e.g. "bindsym"
+	Kind string `json:"kind"`
+
+	// Keys is the key combination as written, e.g. "Mod4+Shift+q"
+	Keys string `json:"keys"`
+
+	// Modifiers are the names sway.Modifiers uses for the modifiers in Keys,
+	// e.g. "Mod4" for "Super" and "Control" for "Ctrl", sorted
+	Modifiers []string `json:"modifiers,omitempty"`
+
+	// Key holds the parts of Keys that aren't modifiers, usually a single key
+	// or button
+	Key []string `json:"key"`
+
+	Release     bool `json:"release,omitempty"`
+	Locked      bool `json:"locked,omitempty"`
+	ToCode      bool `json:"to_code,omitempty"`
+	WholeWindow bool `json:"whole_window,omitempty"`
+
+	// Flags holds all of the flags of the binding, including the ones above
+	Flags []string `json:"flags,omitempty"`
+
+	// Command is the command run by the binding
+	Command string `json:"command"`
+
+	Pos Pos `json:"pos"`
+}
+
+// id returns the fields that sway uses to tell bindings apart
+func (b *Binding) id() string {
+	var (
+		device string
+		flags  = map[string]bool{}
+	)
+
+	for _, f := range b.Flags {
+		if strings.HasPrefix(f, "--input-device=") {
+			device = f
+		}
+		flags[f] = true
+	}
+
+	// sway compares the parts of a window that a mouse binding applies to,
+	// which include the titlebar unless it is excluded
+	titlebar := flags["--whole-window"] || !flags["--exclude-titlebar"]
+	border := flags["--whole-window"] || flags["--border"]
+	state := fmt.Sprintf("%v %v %v %v %v %v",
+		b.Release, flags["--locked"], flags["--inhibited"], border, flags["--whole-window"], titlebar)
+
+	// keysyms aren't case sensitive
+	keys := make([]string, len(b.Key))
+	for i, k := range b.Key {
+		keys[i] = k
+		if strings.HasSuffix(b.Kind, "bindsym") {
+			keys[i] = string(sway.ParseKeysym(k))
+		}
+	}
+
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%s\x00%s",
+		b.Mode, b.Kind, strings.Join(b.Modifiers, "+"), strings.Join(keys, "+"), state, device)
+}
+
+// Bindings returns every binding statement in f, in the order sway reads them.
+// Bindings that are later replaced or removed with an unbind statement are
+// included, use NewInventory to get only the bindings that are in effect.
+func Bindings(f *File) []Binding {
+	var (
+		ret  []Binding
+		vars = map[string]string{}
+	)
+
+	f.Walk(func(s *Statement, blocks []*Statement) bool {
+		if s.Name == "set" && len(s.Words) > 1 {
+			vars[s.Words[0].Value] = Expand(strings.Join(s.Args()[1:], " "), vars)
+			return true
+		}
+
+		if b, ok := newBinding(s, blocks, vars); ok {
+			ret = append(ret, b)
+		}
+
+		return true
+	})
+
+	return ret
+}
+
+func isBind(name string) bool {
+	switch strings.TrimPrefix(name, "un") {
+	case "bindsym", "bindcode", "bindswitch", "bindgesture":
+		return true
+	}
+	return false
+}
+
+func newBinding(s *Statement, blocks []*Statement, vars map[string]string) (Binding, bool) {
+	if s.Body != nil || s.Comment {
+		return Binding{}, false
+	}
+
+	b := Binding{
+		Mode: DefaultMode,
+		Kind: s.Name,
+		Pos:  s.Pos,
+	}
+
+	words := s.Words
+	rest := func(i int) string { return s.Rest(i) }
+
+	// statements inside of a "bindsym { ... }" block don't repeat the name
+	if n := len(blocks); n > 0 && isBind(blocks[n-1].Name) && s.Name != blocks[n-1].Name {
+		b.Kind = blocks[n-1].Name
+		words = append([]Word{{Pos: s.Pos, Value: s.Name}}, s.Words...)
+		rest = func(i int) string {
+			if i == 0 {
+				return strings.TrimSpace(s.Line)
+			}
+			return s.Rest(i - 1)
+		}
+	}
+
+	if !isBind(b.Kind) {
+		return Binding{}, false
+	}
+
+	for _, block := range blocks {
+		if block.Name == "mode" {
+			b.Mode = modeName(block, vars)
+		}
+	}
+
+	i := 0
+	for ; i < len(words) && strings.HasPrefix(words[i].Value, "--"); i++ {
+		flag := words[i].Value
+		b.Flags = append(b.Flags, flag)
+
+		switch flag {
+		case "--release":
+			b.Release = true
+		case "--locked":
+			b.Locked = true
+		case "--to-code":
+			b.ToCode = true
+		case "--whole-window":
+			b.WholeWindow = true
+		}
+	}
+
+	if i == len(words) {
+		return Binding{}, false
+	}
+
+	b.Keys = Expand(words[i].Value, vars)
+	b.Command = Expand(rest(i+1), vars)
+
+	var mods sway.Modifiers
+	for _, part := range strings.Split(b.Keys, "+") {
+		if m, ok := sway.ParseModifier(part); ok {
+			mods |= m
+		} else {
+			b.Key = append(b.Key, part)
+		}
+	}
+
+	b.Modifiers = mods.Names()
+	sort.Strings(b.Modifiers)
+
+	return b, true
+}
+
+// modeName returns the expanded name of a mode block, skipping flags such as
+// --pango_markup
+func modeName(block *Statement, vars map[string]string) string {
+	for _, w := range block.Words {
+		if !strings.HasPrefix(w.Value, "--") {
+			return Expand(w.Value, vars)
+		}
+	}
+	return ""
+}
+
+// Mode holds the bindings of a binding mode
+type Mode struct {
+	Name     string    `json:"name"`
+	Bindings []Binding `json:"bindings"`
+}
+
+// Inventory holds the bindings that are in effect, grouped by mode
+type Inventory struct {
+	Modes []Mode `json:"modes"`
+}
+
+// NewInventory groups bindings by mode. Like sway, a binding replaces an
+// earlier one for the same keys and an unbind statement removes it. The modes
+// are listed in the order given by modes, followed by any other modes that
+// have bindings in the order they first appear.
+func NewInventory(bindings []Binding, modes ...string) *Inventory {
+	var (
+		order   []string
+		byMode  = map[string][]Binding{}
+		seen    = map[string]bool{}
+		addMode = func(name string) {
+			if !seen[name] {
+				seen[name] = true
+				order = append(order, name)
+			}
+		}
+	)
+
+	for _, m := range modes {
+		addMode(m)
+	}
+
+	for _, b := range bindings {
+		unbind := strings.HasPrefix(b.Kind, "un")
+		if unbind {
+			b.Kind = strings.TrimPrefix(b.Kind, "un")
+		}
+
+		list := byMode[b.Mode]
+		for i := range list {
+			if list[i].id() == b.id() {
+				list = append(list[:i], list[i+1:]...)
+				break
+			}
+		}
+
+		if !unbind {
+			addMode(b.Mode)
+			list = append(list, b)
+		}
+
+		byMode[b.Mode] = list
+	}
+
+	inv := &Inventory{Modes: make([]Mode, len(order))}
+	for i, name := range order {
+		inv.Modes[i] = Mode{Name: name, Bindings: byMode[name]}
+		if inv.Modes[i].Bindings == nil {
+			inv.Modes[i].Bindings = []Binding{}
+		}
+	}
+
+	return inv
+}
+
+// LoadInventory parses the config loaded by sway and returns its bindings.
+// sway only returns the contents of the main config file, so like sway, include
+// paths are resolved relative to the directory of that file unless WithDir is
+// passed.
+func LoadInventory(ctx context.Context, c sway.Client, opts ...Option) (*Inventory, error) {
+	f, err := loadConfig(ctx, c, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	modes, err := c.GetBindingModes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInventory(Bindings(f), modes...), nil
+}
+
+// loadConfig parses the config loaded by sway. Relative include paths are
+// resolved in the directory of the loaded config file, unless opts include
+// WithDir.
+func loadConfig(ctx context.Context, c sway.Client, opts []Option) (*File, error) {
+	cfg, err := c.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := c.GetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.LoadedConfigFileName != "" {
+		opts = append([]Option{WithDir(filepath.Dir(version.LoadedConfigFileName))}, opts...)
+	}
+
+	return Parse("", cfg.Config, opts...)
+}
+
+// Markdown writes the inventory as a cheat sheet with a table for each mode
+func (inv *Inventory) Markdown(w io.Writer) error {
+	r := strings.NewReplacer("|", `\|`, "`", "'")
+
+	for i, m := range inv.Modes {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "## %s\n\n", m.Name); err != nil {
+			return err
+		}
+
+		if len(m.Bindings) == 0 {
+			if _, err := io.WriteString(w, "No bindings.\n"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := io.WriteString(w, "| Keys | Command | Flags |\n| --- | --- | --- |\n"); err != nil {
+			return err
+		}
+
+		for _, b := range m.Bindings {
+			_, err := fmt.Fprintf(w, "| `%s` | `%s` | %s |\n",
+				r.Replace(b.Keys), r.Replace(b.Command), r.Replace(strings.Join(b.Flags, " ")))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
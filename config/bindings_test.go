@@ -0,0 +1,220 @@
+package config_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+	"github.com/joshuarubin/go-sway/config"
+)
+
+type fakeClient struct {
+	sway.Client
+	config string
+	loaded string
+	modes  []string
+}
+
+func (c *fakeClient) GetConfig(context.Context) (*sway.Config, error) {
+	return &sway.Config{Config: c.config}, nil
+}
+
+func (c *fakeClient) GetVersion(context.Context) (*sway.Version, error) {
+	return &sway.Version{LoadedConfigFileName: c.loaded}, nil
+}
+
+func (c *fakeClient) GetBindingModes(context.Context) ([]string, error) {
+	return c.modes, nil
+}
+
+const bindingsConfig = `set $mod Mod4
+set $mode_system System (l) lock, (e) exit
+
+bindsym $mod+Return exec foot
+bindsym --to-code $mod+Shift+q kill
+bindsym $mod+Return exec alacritty
+bindsym Super+d exec "wofi --show drun"
+bindsym --release Print exec grim
+unbindsym --release Print
+bindsym --whole-window --locked $mod+button2 kill
+bindsym $mod+Escape mode "$mode_system"
+
+mode --pango_markup "$mode_system" {
+    bindsym {
+        l exec swaylock, mode default
+        Escape mode default
+    }
+}
+`
+
+func TestLoadInventory(t *testing.T) {
+	c := &fakeClient{
+		config: bindingsConfig,
+		modes:  []string{"default", "resize", "System (l) lock, (e) exit"},
+	}
+
+	inv, err := config.LoadInventory(context.Background(), c, config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(inv.Modes); n != 3 {
+		t.Fatalf("got %d modes, want 3", n)
+	}
+
+	def := inv.Modes[0]
+	var got []string
+	for _, b := range def.Bindings {
+		got = append(got, b.Keys+" => "+b.Command)
+	}
+
+	want := []string{
+		"Mod4+Shift+q => kill",
+		"Mod4+Return => exec alacritty",
+		`Super+d => exec "wofi --show drun"`,
+		"Mod4+button2 => kill",
+		`Mod4+Escape => mode "System (l) lock, (e) exit"`,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("default mode:\ngot  %q\nwant %q", got, want)
+	}
+
+	if b := def.Bindings[0]; !b.ToCode || !reflect.DeepEqual(b.Modifiers, []string{"Mod4", "Shift"}) || !reflect.DeepEqual(b.Key, []string{"q"}) {
+		t.Errorf("unexpected binding %+v", b)
+	}
+
+	if b := def.Bindings[2]; !reflect.DeepEqual(b.Modifiers, []string{"Mod4"}) {
+		t.Errorf("Super was not mapped to Mod4: %+v", b)
+	}
+
+	if b := def.Bindings[3]; !b.WholeWindow || !b.Locked || b.Release {
+		t.Errorf("unexpected flags %+v", b)
+	}
+
+	if m := inv.Modes[1]; m.Name != "resize" || len(m.Bindings) != 0 {
+		t.Errorf("unexpected resize mode %+v", m)
+	}
+
+	sys := inv.Modes[2]
+	if len(sys.Bindings) != 2 || sys.Bindings[0].Keys != "l" || sys.Bindings[0].Command != "exec swaylock, mode default" || sys.Bindings[0].Kind != "bindsym" {
+		t.Errorf("unexpected system mode %+v", sys)
+	}
+
+	var md strings.Builder
+	if err := inv.Markdown(&md); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"## default\n",
+		"| `Mod4+Shift+q` | `kill` | --to-code |\n",
+		"## resize\n\nNo bindings.\n",
+	} {
+		if !strings.Contains(md.String(), line) {
+			t.Errorf("markdown does not contain %q:\n%s", line, md.String())
+		}
+	}
+
+	data, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded config.Inventory
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(&decoded, inv) {
+		t.Errorf("json round trip changed the inventory:\n%s", data)
+	}
+}
+
+func TestInventoryFlags(t *testing.T) {
+	// sway keeps a binding for each of these flags next to the one without it
+	for _, flag := range []string{"--release", "--locked", "--inhibited", "--whole-window", "--border", "--exclude-titlebar"} {
+		cfg := "bindsym XF86AudioMute exec a\nbindsym " + flag + " XF86AudioMute exec b\n" +
+			"bindsym button4 exec c\nbindsym " + flag + " button4 exec d\n"
+
+		f, err := config.Parse("cfg", cfg, config.WithoutIncludes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n := len(config.NewInventory(config.Bindings(f)).Modes[0].Bindings); n != 4 {
+			t.Errorf("%s: got %d bindings, want 4", flag, n)
+		}
+	}
+
+	// flags that sway doesn't compare still replace the binding
+	f, err := config.Parse("cfg", "bindsym q exec a\nbindsym --no-warn --no-repeat q exec b\n", config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b := config.NewInventory(config.Bindings(f)).Modes[0].Bindings; len(b) != 1 || b[0].Command != "exec b" {
+		t.Errorf("unexpected bindings %+v", b)
+	}
+}
+
+func TestLoadInventoryInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sway-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "keys.conf"), []byte("bindsym Mod4+q kill\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// includes are resolved next to the loaded config, not in the current
+	// directory
+	c := &fakeClient{
+		config: "include keys.conf\n",
+		loaded: filepath.Join(dir, "config"),
+	}
+
+	inv, err := config.LoadInventory(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inv.Modes) != 1 || len(inv.Modes[0].Bindings) != 1 || inv.Modes[0].Bindings[0].Keys != "Mod4+q" {
+		t.Errorf("unexpected inventory %+v", inv)
+	}
+
+	// WithDir takes precedence
+	if inv, err = config.LoadInventory(context.Background(), c, config.WithDir(os.TempDir())); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inv.Modes) != 0 {
+		t.Errorf("unexpected inventory %+v", inv)
+	}
+}
+
+func TestBindingModifiers(t *testing.T) {
+	f, err := config.Parse("cfg", "bindsym Ctrl+alt+Super+Mod4+q kill\n", config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the names are the ones the sway package uses for the same binding
+	b := config.Bindings(f)[0]
+	if want := []string{"Control", "Mod1", "Mod4"}; !reflect.DeepEqual(b.Modifiers, want) {
+		t.Errorf("got modifiers %q, want %q", b.Modifiers, want)
+	}
+
+	mods, err := sway.ParseModifiers(b.Modifiers)
+	if err != nil || mods != sway.ModControl|sway.ModAlt|sway.ModSuper {
+		t.Errorf("got %v, %v", mods, err)
+	}
+}
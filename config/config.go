@@ -0,0 +1,232 @@
+// Package config parses sway config files, such as the one returned by
+// Client.GetConfig, into a syntax tree that can be inspected and written back.
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Pos is a position in a config file. Line and Column start at 1 and Column
+// counts runes.
+type Pos struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+func (p Pos) String() string {
+	s := fmt.Sprintf("%d:%d", p.Line, p.Column)
+	if p.File != "" {
+		s = p.File + ":" + s
+	}
+	return s
+}
+
+// Error is a syntax error in a config file
+type Error struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// A Word is a single argument of a statement
+type Word struct {
+	Pos Pos
+
+	// Raw is the word as it is written in the file, including quotes
+	Raw string
+
+	// Value is the word without quotes and escapes
+	Value string
+
+	offset int
+}
+
+// A Statement is a single line of a config file, or a block if Body is not nil
+type Statement struct {
+	Pos Pos
+
+	// Comment is true if the statement is a comment. Line holds its text,
+	// including the leading '#'.
+	Comment bool
+
+	// Name is the first word of the statement, e.g. "bindsym" or "output"
+	Name string
+
+	// Words are the words that follow Name. For blocks, the opening brace is
+	// not included.
+	Words []Word
+
+	// Line is the text of the statement with line continuations joined and
+	// leading whitespace removed. For blocks, the opening brace is not
+	// included.
+	Line string
+
+	// Body holds the statements inside of a block. It is nil for statements
+	// that aren't blocks.
+	Body []*Statement
+
+	// Includes holds the files matched by an include statement. Files that
+	// were already included are skipped, like sway does.
+	Includes []*File
+}
+
+// IsBlock returns true if s is a block such as mode, bar or input
+func (s *Statement) IsBlock() bool {
+	return s.Body != nil
+}
+
+// Args returns the values of the words of s
+func (s *Statement) Args() []string {
+	ret := make([]string, len(s.Words))
+	for i, w := range s.Words {
+		ret[i] = w.Value
+	}
+	return ret
+}
+
+// Flags returns the leading words of s that start with "--", such as the
+// --release flag of bindsym
+func (s *Statement) Flags() []string {
+	var ret []string
+	for _, w := range s.Words {
+		if !strings.HasPrefix(w.Value, "--") {
+			break
+		}
+		ret = append(ret, w.Value)
+	}
+	return ret
+}
+
+// Rest returns the text of s from word i to the end of the line, as it is
+// written in the file. This is the command of a binding or for_window rule.
+func (s *Statement) Rest(i int) string {
+	if i >= len(s.Words) {
+		return ""
+	}
+	return strings.TrimSpace(s.Line[s.Words[i].offset:])
+}
+
+// A File is a parsed config file
+type File struct {
+	Name       string
+	Statements []*Statement
+}
+
+// Walk calls fn for each statement in f, in order, including the statements
+// in blocks and included files. The second argument holds the blocks that
+// contain the statement, outermost first. If fn returns false, the body and
+// includes of the statement are skipped.
+func (f *File) Walk(fn func(s *Statement, blocks []*Statement) bool) {
+	walk(f.Statements, nil, fn)
+}
+
+func walk(stmts []*Statement, blocks []*Statement, fn func(*Statement, []*Statement) bool) {
+	for _, s := range stmts {
+		if !fn(s, blocks) {
+			continue
+		}
+
+		if s.Body != nil {
+			walk(s.Body, append(blocks[:len(blocks):len(blocks)], s), fn)
+		}
+
+		for _, inc := range s.Includes {
+			walk(inc.Statements, blocks, fn)
+		}
+	}
+}
+
+// Vars returns the variables set in f and its included files. Like sway, the
+// value of each variable is expanded using the variables set before it. If a
+// variable is set more than once, the last value is returned.
+func (f *File) Vars() map[string]string {
+	vars := map[string]string{}
+	f.Walk(func(s *Statement, _ []*Statement) bool {
+		if s.Name == "set" && len(s.Words) > 1 {
+			vars[s.Words[0].Value] = Expand(strings.Join(s.Args()[1:], " "), vars)
+		}
+		return true
+	})
+	return vars
+}
+
+// Expand replaces the variables in s with their values. Like sway, the longest
+// variable name that matches is used, so $mod2 is not expanded as $mod
+// followed by "2".
+func Expand(s string, vars map[string]string) string {
+	if !strings.Contains(s, "$") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		var name string
+		for n := range vars {
+			if len(n) > len(name) && strings.HasPrefix(s[i:], n) {
+				name = n
+			}
+		}
+
+		if name == "" {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		b.WriteString(vars[name])
+		i += len(name) - 1
+	}
+
+	return b.String()
+}
+
+// Format writes f as a config file with blocks indented by four spaces.
+// Included files are not written, only the include statements.
+func (f *File) Format(w io.Writer) error {
+	return format(w, f.Statements, 0)
+}
+
+func (f *File) String() string {
+	var b strings.Builder
+	_ = f.Format(&b)
+	return b.String()
+}
+
+func format(w io.Writer, stmts []*Statement, depth int) error {
+	indent := strings.Repeat("    ", depth)
+
+	for _, s := range stmts {
+		line := s.Line
+		if s.Body != nil {
+			line += " {"
+		}
+
+		if _, err := io.WriteString(w, indent+line+"\n"); err != nil {
+			return err
+		}
+
+		if s.Body == nil {
+			continue
+		}
+
+		if err := format(w, s.Body, depth+1); err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, indent+"}\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,356 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind tells whether something was added, removed or changed
+type ChangeKind string
+
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+	Changed ChangeKind = "changed"
+)
+
+// BindingChange is a binding that differs between two configs. Old is nil for
+// added bindings and New is nil for removed ones.
+type BindingChange struct {
+	Kind ChangeKind `json:"kind"`
+	Old  *Binding   `json:"old,omitempty"`
+	New  *Binding   `json:"new,omitempty"`
+}
+
+// ModeChange is a binding mode that was added or removed
+type ModeChange struct {
+	Kind ChangeKind `json:"kind"`
+	Name string     `json:"name"`
+}
+
+// VarChange is a variable that differs between two configs
+type VarChange struct {
+	Kind ChangeKind `json:"kind"`
+	Name string     `json:"name"`
+	Old  string     `json:"old,omitempty"`
+	New  string     `json:"new,omitempty"`
+}
+
+// BlockChange is an input, output, seat or bar whose settings differ between
+// two configs. The settings are the expanded statements of the block, or of
+// the single line form such as "output * bg x fill", without the name and
+// identifier. Settings of nested blocks, such as the colors of a bar, are
+// prefixed with the name of the block.
+type BlockChange struct {
+	Kind ChangeKind `json:"kind"`
+
+	// ID is the identifier of the input, output, seat or bar, e.g. "*" or
+	// "type:touchpad"
+	ID string `json:"id"`
+
+	Old []string `json:"old,omitempty"`
+	New []string `json:"new,omitempty"`
+}
+
+// Diff holds the differences between two configs
+type Diff struct {
+	Bindings []BindingChange `json:"bindings,omitempty"`
+	Modes    []ModeChange    `json:"modes,omitempty"`
+	Vars     []VarChange     `json:"vars,omitempty"`
+	Inputs   []BlockChange   `json:"inputs,omitempty"`
+	Outputs  []BlockChange   `json:"outputs,omitempty"`
+	Seats    []BlockChange   `json:"seats,omitempty"`
+	Bars     []BlockChange   `json:"bars,omitempty"`
+}
+
+// Empty returns true if there are no differences
+func (d *Diff) Empty() bool {
+	return len(d.Bindings) == 0 && len(d.Modes) == 0 && len(d.Vars) == 0 &&
+		len(d.Inputs) == 0 && len(d.Outputs) == 0 && len(d.Seats) == 0 && len(d.Bars) == 0
+}
+
+// Compare returns the differences between the old and new configs. Bindings
+// are compared once replaced and unbound bindings are removed, and positions
+// are ignored so that moving a statement is not a change.
+func Compare(old, new *File) *Diff {
+	var d Diff
+
+	oldInv, newInv := NewInventory(Bindings(old)), NewInventory(Bindings(new))
+	d.Bindings = compareBindings(oldInv, newInv)
+	d.Modes = compareModes(old, new)
+	d.Vars = compareVars(old.Vars(), new.Vars())
+
+	oldBlocks, newBlocks := collectBlocks(old), collectBlocks(new)
+	d.Inputs = compareBlocks(oldBlocks["input"], newBlocks["input"])
+	d.Outputs = compareBlocks(oldBlocks["output"], newBlocks["output"])
+	d.Seats = compareBlocks(oldBlocks["seat"], newBlocks["seat"])
+	d.Bars = compareBlocks(oldBlocks["bar"], newBlocks["bar"])
+
+	return &d
+}
+
+func compareBindings(old, new *Inventory) []BindingChange {
+	var (
+		ret    []BindingChange
+		oldIDs = map[string]*Binding{}
+		newIDs = map[string]bool{}
+	)
+
+	for _, m := range old.Modes {
+		for i := range m.Bindings {
+			oldIDs[m.Bindings[i].id()] = &m.Bindings[i]
+		}
+	}
+
+	for _, m := range new.Modes {
+		for i := range m.Bindings {
+			b := &m.Bindings[i]
+			id := b.id()
+			newIDs[id] = true
+
+			o, ok := oldIDs[id]
+			switch {
+			case !ok:
+				ret = append(ret, BindingChange{Kind: Added, New: b})
+			case o.Command != b.Command || !reflect.DeepEqual(o.Flags, b.Flags):
+				ret = append(ret, BindingChange{Kind: Changed, Old: o, New: b})
+			}
+		}
+	}
+
+	for _, m := range old.Modes {
+		for i := range m.Bindings {
+			if b := &m.Bindings[i]; !newIDs[b.id()] {
+				ret = append(ret, BindingChange{Kind: Removed, Old: b})
+			}
+		}
+	}
+
+	return ret
+}
+
+// modes returns the names of the mode blocks in f, in order, and where each of
+// them is first defined
+func modes(f *File) ([]string, map[string]Pos) {
+	var (
+		ret  []string
+		pos  = map[string]Pos{}
+		vars = map[string]string{}
+	)
+
+	f.Walk(func(s *Statement, _ []*Statement) bool {
+		switch {
+		case s.Name == "set" && len(s.Words) > 1:
+			vars[s.Words[0].Value] = Expand(strings.Join(s.Args()[1:], " "), vars)
+		case s.Name == "mode" && s.Body != nil:
+			name := modeName(s, vars)
+			if _, ok := pos[name]; !ok {
+				pos[name] = s.Pos
+				ret = append(ret, name)
+			}
+		}
+		return true
+	})
+
+	return ret, pos
+}
+
+func compareModes(old, new *File) []ModeChange {
+	var ret []ModeChange
+
+	oldModes, _ := modes(old)
+	newModes, _ := modes(new)
+
+	for _, m := range newModes {
+		if !contains(oldModes, m) {
+			ret = append(ret, ModeChange{Kind: Added, Name: m})
+		}
+	}
+
+	for _, m := range oldModes {
+		if !contains(newModes, m) {
+			ret = append(ret, ModeChange{Kind: Removed, Name: m})
+		}
+	}
+
+	return ret
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func compareVars(old, new map[string]string) []VarChange {
+	var ret []VarChange
+
+	for name, v := range new {
+		o, ok := old[name]
+		switch {
+		case !ok:
+			ret = append(ret, VarChange{Kind: Added, Name: name, New: v})
+		case o != v:
+			ret = append(ret, VarChange{Kind: Changed, Name: name, Old: o, New: v})
+		}
+	}
+
+	for name, o := range old {
+		if _, ok := new[name]; !ok {
+			ret = append(ret, VarChange{Kind: Removed, Name: name, Old: o})
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+
+	return ret
+}
+
+// block holds the settings of an input, output, seat or bar
+type block struct {
+	id       string
+	settings []string
+}
+
+// collectBlocks returns the settings of each input, output, seat and bar in f,
+// keyed by statement name
+func collectBlocks(f *File) map[string][]*block {
+	var (
+		ret   = map[string][]*block{}
+		byID  = map[string]*block{}
+		vars  = map[string]string{}
+		nbars int
+	)
+
+	get := func(name, id string) *block {
+		key := name + "\x00" + id
+		if b, ok := byID[key]; ok {
+			return b
+		}
+		b := &block{id: id}
+		byID[key] = b
+		ret[name] = append(ret[name], b)
+		return b
+	}
+
+	f.Walk(func(s *Statement, blocks []*Statement) bool {
+		if s.Name == "set" && len(s.Words) > 1 {
+			vars[s.Words[0].Value] = Expand(strings.Join(s.Args()[1:], " "), vars)
+			return true
+		}
+
+		if len(blocks) > 0 || s.Comment {
+			return true
+		}
+
+		switch s.Name {
+		case "input", "output", "seat":
+			if len(s.Words) == 0 {
+				return false
+			}
+
+			b := get(s.Name, Expand(s.Words[0].Value, vars))
+			if s.Body == nil {
+				b.settings = append(b.settings, Expand(s.Rest(1), vars))
+				return false
+			}
+
+			b.settings = append(b.settings, settings(s.Body, "", vars)...)
+			return false
+		case "bar":
+			id := barID(s, vars, nbars)
+			nbars++
+
+			b := get(s.Name, id)
+			if s.Body == nil {
+				b.settings = append(b.settings, Expand(s.Rest(1), vars))
+				return false
+			}
+
+			b.settings = append(b.settings, settings(s.Body, "", vars)...)
+			return false
+		}
+
+		return true
+	})
+
+	return ret
+}
+
+// barID returns the id of a bar like sway does: the id set in the bar block or
+// "bar-N", where N counts the bars in the config
+func barID(s *Statement, vars map[string]string, n int) string {
+	if s.Body == nil {
+		if len(s.Words) > 0 {
+			return Expand(s.Words[0].Value, vars)
+		}
+		return ""
+	}
+
+	for _, st := range s.Body {
+		if st.Name == "id" && len(st.Words) > 0 {
+			return Expand(st.Words[0].Value, vars)
+		}
+	}
+
+	if len(s.Words) > 0 {
+		return Expand(s.Words[0].Value, vars)
+	}
+
+	return "bar-" + strconv.Itoa(n)
+}
+
+func settings(stmts []*Statement, prefix string, vars map[string]string) []string {
+	var ret []string
+
+	for _, s := range stmts {
+		if s.Comment {
+			continue
+		}
+
+		line := prefix + Expand(s.Line, vars)
+		if s.Body == nil {
+			ret = append(ret, line)
+			continue
+		}
+
+		ret = append(ret, settings(s.Body, line+" ", vars)...)
+	}
+
+	return ret
+}
+
+func compareBlocks(old, new []*block) []BlockChange {
+	var ret []BlockChange
+
+	oldByID := map[string]*block{}
+	for _, b := range old {
+		oldByID[b.id] = b
+	}
+
+	newIDs := map[string]bool{}
+	for _, b := range new {
+		newIDs[b.id] = true
+
+		o, ok := oldByID[b.id]
+		switch {
+		case !ok:
+			ret = append(ret, BlockChange{Kind: Added, ID: b.id, New: b.settings})
+		case !reflect.DeepEqual(o.settings, b.settings):
+			ret = append(ret, BlockChange{Kind: Changed, ID: b.id, Old: o.settings, New: b.settings})
+		}
+	}
+
+	for _, b := range old {
+		if !newIDs[b.id] {
+			ret = append(ret, BlockChange{Kind: Removed, ID: b.id, Old: b.settings})
+		}
+	}
+
+	return ret
+}
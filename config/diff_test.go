@@ -0,0 +1,229 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+	"github.com/joshuarubin/go-sway/config"
+)
+
+const beforeReload = `set $mod Mod4
+set $term foot
+bindsym $mod+Return exec $term
+bindsym $mod+q kill
+bindsym $mod+r mode resize
+
+mode resize {
+    bindsym Escape mode default
+}
+
+input type:touchpad {
+    tap enabled
+}
+
+output * bg ~/a.png fill
+
+bar {
+    position top
+    colors {
+        background #000000
+    }
+}
+`
+
+const afterReload = `set $mod Mod4
+set $term alacritty
+set $menu wofi
+bindsym $mod+Return exec $term
+bindsym $mod+d exec $menu
+
+input type:touchpad {
+    tap enabled
+    natural_scroll enabled
+}
+
+output * bg ~/a.png fill
+output HDMI-A-1 scale 2
+
+bar {
+    position top
+    colors {
+        background #111111
+    }
+}
+`
+
+type reloadClient struct {
+	sway.Client
+	configs []string
+	loaded  string
+}
+
+func (c *reloadClient) GetVersion(context.Context) (*sway.Version, error) {
+	return &sway.Version{LoadedConfigFileName: c.loaded}, nil
+}
+
+func (c *reloadClient) GetConfig(context.Context) (*sway.Config, error) {
+	if len(c.configs) == 0 {
+		return nil, errors.New("no config")
+	}
+
+	cfg := c.configs[0]
+	c.configs = c.configs[1:]
+
+	return &sway.Config{Config: cfg}, nil
+}
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	c := &reloadClient{configs: []string{beforeReload, afterReload}}
+
+	var (
+		diffs []*config.Diff
+		errs  []error
+	)
+
+	w, err := config.NewWatcher(ctx, c, func(_ context.Context, d *config.Diff, err error) {
+		diffs = append(diffs, d)
+		errs = append(errs, err)
+	}, config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w.Workspace(ctx, sway.WorkspaceEvent{Change: sway.WorkspaceFocus})
+	if len(diffs) != 0 {
+		t.Fatal("focus event caused a reload")
+	}
+
+	w.Workspace(ctx, sway.WorkspaceEvent{Change: sway.WorkspaceReload})
+	if len(diffs) != 1 || errs[0] != nil {
+		t.Fatalf("got %d diffs, errors %v", len(diffs), errs)
+	}
+
+	d := diffs[0]
+
+	var bindings []string
+	for _, b := range d.Bindings {
+		switch b.Kind {
+		case config.Added, config.Changed:
+			bindings = append(bindings, string(b.Kind)+" "+b.New.Keys+" "+b.New.Command)
+		case config.Removed:
+			bindings = append(bindings, string(b.Kind)+" "+b.Old.Keys+" "+b.Old.Command)
+		}
+	}
+
+	wantBindings := []string{
+		"changed Mod4+Return exec alacritty",
+		"added Mod4+d exec wofi",
+		"removed Mod4+q kill",
+		"removed Mod4+r mode resize",
+		"removed Escape mode default",
+	}
+
+	if !reflect.DeepEqual(bindings, wantBindings) {
+		t.Errorf("bindings:\ngot  %q\nwant %q", bindings, wantBindings)
+	}
+
+	if want := []config.ModeChange{{Kind: config.Removed, Name: "resize"}}; !reflect.DeepEqual(d.Modes, want) {
+		t.Errorf("modes: got %+v, want %+v", d.Modes, want)
+	}
+
+	wantVars := []config.VarChange{
+		{Kind: config.Added, Name: "$menu", New: "wofi"},
+		{Kind: config.Changed, Name: "$term", Old: "foot", New: "alacritty"},
+	}
+
+	if !reflect.DeepEqual(d.Vars, wantVars) {
+		t.Errorf("vars: got %+v, want %+v", d.Vars, wantVars)
+	}
+
+	wantInputs := []config.BlockChange{{
+		Kind: config.Changed,
+		ID:   "type:touchpad",
+		Old:  []string{"tap enabled"},
+		New:  []string{"tap enabled", "natural_scroll enabled"},
+	}}
+
+	if !reflect.DeepEqual(d.Inputs, wantInputs) {
+		t.Errorf("inputs: got %+v, want %+v", d.Inputs, wantInputs)
+	}
+
+	wantOutputs := []config.BlockChange{{Kind: config.Added, ID: "HDMI-A-1", New: []string{"scale 2"}}}
+	if !reflect.DeepEqual(d.Outputs, wantOutputs) {
+		t.Errorf("outputs: got %+v, want %+v", d.Outputs, wantOutputs)
+	}
+
+	wantBars := []config.BlockChange{{
+		Kind: config.Changed,
+		ID:   "bar-0",
+		Old:  []string{"position top", "colors background #000000"},
+		New:  []string{"position top", "colors background #111111"},
+	}}
+
+	if !reflect.DeepEqual(d.Bars, wantBars) {
+		t.Errorf("bars: got %+v, want %+v", d.Bars, wantBars)
+	}
+
+	// a failed reload keeps the last config
+	w.Reload(ctx)
+	if len(errs) != 2 || errs[1] == nil || diffs[1] != nil {
+		t.Errorf("expected the failed reload to be reported, got %v", errs)
+	}
+
+	if cfg := w.Config(); len(cfg.Statements) == 0 || cfg.Statements[1].Args()[1] != "alacritty" {
+		t.Error("the watcher did not keep the last config")
+	}
+
+	if d := config.Compare(w.Config(), w.Config()); !d.Empty() {
+		t.Errorf("comparing a config to itself returned %+v", d)
+	}
+}
+
+func TestWatcherInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sway-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys := filepath.Join(dir, "keys.conf")
+	if err = ioutil.WriteFile(keys, []byte("bindsym Mod4+q kill\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &reloadClient{
+		configs: []string{"include keys.conf\n", "include keys.conf\n"},
+		loaded:  filepath.Join(dir, "config"),
+	}
+
+	var diffs []*config.Diff
+
+	w, err := config.NewWatcher(context.Background(), c, func(_ context.Context, d *config.Diff, err error) {
+		if err != nil {
+			t.Error(err)
+		}
+		diffs = append(diffs, d)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the included file is read next to the loaded config, so a change to it
+	// shows up in the diff
+	if err = ioutil.WriteFile(keys, []byte("bindsym Mod4+q exec true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	w.Reload(context.Background())
+
+	if len(diffs) != 1 || len(diffs[0].Bindings) != 1 || diffs[0].Bindings[0].Kind != config.Changed {
+		t.Errorf("unexpected diffs %+v", diffs)
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+// KeycodeFunc returns the XKB keycode that produces keysym in the first layout
+// of the keyboard, as used by bindsym --to-code
+type KeycodeFunc func(keysym string) (int, bool)
+
+// USKeycodes resolves keysyms with the "us" layout of the evdev keymap. The
+// keysym is normalized with sway.ParseKeysym first.
+func USKeycodes(keysym string) (int, bool) {
+	code, ok := usKeycodes[strings.ToLower(string(sway.ParseKeysym(keysym)))]
+	return code, ok
+}
+
+var usKeycodes = map[string]int{}
+
+func init() {
+	rows := []struct {
+		first int
+		keys  string
+	}{
+		{10, "1234567890-="},
+		{24, "qwertyuiop[]"},
+		{38, "asdfghjkl;'`"},
+		{51, `\zxcvbnm,./`},
+	}
+
+	shifted := []struct {
+		first int
+		keys  string
+	}{
+		{10, "!@#$%^&*()_+"},
+		{34, "{}"},
+		{47, `:"~`},
+		{51, "|"},
+		{59, "<>?"},
+	}
+
+	for _, r := range append(rows, shifted...) {
+		for i, ch := range r.keys {
+			usKeycodes[string(sway.ParseKeysym(string(ch)))] = r.first + i
+		}
+	}
+
+	for i := 1; i <= 10; i++ {
+		usKeycodes["f"+strconv.Itoa(i)] = 66 + i
+	}
+	usKeycodes["f11"] = 95
+	usKeycodes["f12"] = 96
+
+	for name, code := range map[string]int{
+		"escape":    9,
+		"backspace": 22,
+		"tab":       23,
+		"return":    36,
+		"control_l": 37,
+		"shift_l":   50,
+		"shift_r":   62,
+		"alt_l":     64,
+		"space":     65,
+		"caps_lock": 66,
+		"home":      110,
+		"up":        111,
+		"prior":     112,
+		"page_up":   112,
+		"left":      113,
+		"right":     114,
+		"end":       115,
+		"down":      116,
+		"next":      117,
+		"page_down": 117,
+		"insert":    118,
+		"delete":    119,
+		"print":     107,
+		"super_l":   133,
+		"super_r":   134,
+		"menu":      135,
+
+		"xf86audiomute":         121,
+		"xf86audiolowervolume":  122,
+		"xf86audioraisevolume":  123,
+		"xf86poweroff":          124,
+		"xf86calculator":        148,
+		"xf86mail":              163,
+		"xf86audionext":         171,
+		"xf86audioplay":         172,
+		"xf86audioprev":         173,
+		"xf86audiostop":         174,
+		"xf86audiomicmute":      198,
+		"xf86search":            225,
+		"xf86monbrightnessdown": 232,
+		"xf86monbrightnessup":   233,
+	} {
+		usKeycodes[name] = code
+	}
+}
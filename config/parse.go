@@ -0,0 +1,463 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Option can be passed to Parse and ParseFile to change how a config is parsed
+type Option func(*parser)
+
+// WithDir sets the directory that relative include paths are resolved in. It
+// defaults to the directory of the file being parsed.
+func WithDir(dir string) Option {
+	return func(p *parser) {
+		p.dir = dir
+	}
+}
+
+// WithoutIncludes keeps include statements from being resolved, so the parser
+// does not read any files
+func WithoutIncludes() Option {
+	return func(p *parser) {
+		p.noIncludes = true
+	}
+}
+
+// ParseFile reads and parses the config file at path
+func ParseFile(path string, opts ...Option) (*File, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(path, string(src), opts...)
+}
+
+// Parse parses src, the contents of the config file called name. The name is
+// used in positions and to resolve relative include paths. It can be empty,
+// e.g. when parsing the config returned by Client.GetConfig, in which case
+// includes are resolved relative to the directory set with WithDir.
+func Parse(name, src string, opts ...Option) (*File, error) {
+	p := parser{
+		included: map[string]bool{},
+		vars:     map[string]string{},
+	}
+
+	if name != "" {
+		p.dir = filepath.Dir(name)
+	}
+
+	for _, opt := range opts {
+		opt(&p)
+	}
+
+	if name != "" {
+		if abs, err := filepath.Abs(name); err == nil {
+			p.included[abs] = true
+		}
+	}
+
+	return p.parse(name, src, p.dir)
+}
+
+type parser struct {
+	dir        string
+	noIncludes bool
+	included   map[string]bool
+	vars       map[string]string
+}
+
+// segment is the part of a logical line that comes from a single line of the
+// file
+type segment struct {
+	offset int
+	line   int
+	text   string
+}
+
+// logicalLine is a line with its continuations joined
+type logicalLine struct {
+	file     string
+	text     string
+	segments []segment
+}
+
+// pos returns the position of the byte at offset in l
+func (l *logicalLine) pos(offset int) Pos {
+	seg := l.segments[0]
+	for _, s := range l.segments[1:] {
+		if s.offset > offset {
+			break
+		}
+		seg = s
+	}
+
+	col := offset - seg.offset
+	if col > len(seg.text) {
+		col = len(seg.text)
+	}
+
+	return Pos{
+		File:   l.file,
+		Line:   seg.line,
+		Column: utf8.RuneCountInString(seg.text[:col]) + 1,
+	}
+}
+
+// splitLines splits src into logical lines. A line that ends with a backslash
+// continues on the next line.
+func splitLines(name, src string) []*logicalLine {
+	var (
+		ret []*logicalLine
+		cur *logicalLine
+	)
+
+	lines := strings.Split(strings.Replace(src, "\r\n", "\n", -1), "\n")
+	for i, text := range lines {
+		if cur == nil {
+			cur = &logicalLine{file: name}
+		}
+
+		cont := strings.HasSuffix(text, `\`)
+		if cont {
+			text = text[:len(text)-1]
+		}
+
+		cur.segments = append(cur.segments, segment{offset: len(cur.text), line: i + 1, text: text})
+		cur.text += text
+
+		if !cont || i == len(lines)-1 {
+			ret = append(ret, cur)
+			cur = nil
+		}
+	}
+
+	return ret
+}
+
+const whitespace = " \t\r\n"
+
+func (p *parser) parse(name, src, dir string) (*File, error) {
+	var (
+		f     = &File{Name: name}
+		stack []*Statement
+		lines = splitLines(name, src)
+	)
+
+	add := func(s *Statement) {
+		if len(stack) == 0 {
+			f.Statements = append(f.Statements, s)
+			return
+		}
+		top := stack[len(stack)-1]
+		top.Body = append(top.Body, s)
+	}
+
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+
+		t := strings.TrimLeft(l.text, whitespace)
+		lead := len(l.text) - len(t)
+		t = strings.TrimRight(t, whitespace)
+
+		switch {
+		case t == "":
+			continue
+		case t[0] == '#':
+			add(&Statement{Pos: l.pos(lead), Comment: true, Line: t})
+			continue
+		case t == "}":
+			if len(stack) == 0 {
+				return nil, &Error{Pos: l.pos(lead), Msg: "unexpected '}'"}
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		case t == "{":
+			return nil, &Error{Pos: l.pos(lead), Msg: "unexpected '{'"}
+		}
+
+		open := strings.HasSuffix(t, "{")
+		if open {
+			t = strings.TrimRight(t[:len(t)-1], whitespace)
+		} else if j := nextLine(lines, i); j >= 0 && strings.TrimSpace(lines[j].text) == "{" {
+			open = true
+			i = j
+		}
+
+		words, err := tokenize(l, lead, t)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(words) == 0 {
+			return nil, &Error{Pos: l.pos(lead), Msg: "block without a name"}
+		}
+
+		s := &Statement{
+			Pos:   words[0].Pos,
+			Name:  words[0].Value,
+			Words: words[1:],
+			Line:  t,
+		}
+
+		if err := p.check(s, open); err != nil {
+			return nil, err
+		}
+
+		add(s)
+
+		if open {
+			s.Body = []*Statement{}
+			stack = append(stack, s)
+			continue
+		}
+
+		switch s.Name {
+		case "set":
+			p.vars[s.Words[0].Value] = Expand(strings.Join(s.Args()[1:], " "), p.vars)
+		case "include":
+			if err := p.include(s, dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return nil, &Error{Pos: top.Pos, Msg: fmt.Sprintf("%s block is never closed", top.Name)}
+	}
+
+	return f, nil
+}
+
+// nextLine returns the index of the next line after i that isn't empty or a
+// comment, or -1
+func nextLine(lines []*logicalLine, i int) int {
+	for j := i + 1; j < len(lines); j++ {
+		t := strings.TrimSpace(lines[j].text)
+		if t != "" && t[0] != '#' {
+			return j
+		}
+	}
+	return -1
+}
+
+// tokenize splits t, which starts at offset lead of l, into words. Quotes and
+// escapes follow the rules sway uses for commands.
+func tokenize(l *logicalLine, lead int, t string) ([]Word, error) {
+	var (
+		ret              []Word
+		inString, inChar bool
+		escaped          bool
+		start, quote     = -1, -1
+	)
+
+	end := func(i int) {
+		raw := t[start:i]
+		ret = append(ret, Word{
+			Pos:    l.pos(lead + start),
+			Raw:    raw,
+			Value:  unquote(raw),
+			offset: start,
+		})
+		start = -1
+	}
+
+	for i := 0; i < len(t); i++ {
+		ch := t[i]
+
+		if !inString && !inChar && !escaped && strings.IndexByte(whitespace, ch) >= 0 {
+			if start >= 0 {
+				end(i)
+			}
+			continue
+		}
+
+		if start < 0 {
+			start = i
+		}
+
+		switch {
+		case ch == '"' && !inChar && !escaped:
+			inString = !inString
+			quote = i
+		case ch == '\'' && !inString && !escaped:
+			inChar = !inChar
+			quote = i
+		}
+
+		escaped = ch == '\\' && !escaped
+	}
+
+	if inString || inChar {
+		return nil, &Error{Pos: l.pos(lead + quote), Msg: "unterminated quote"}
+	}
+
+	if start >= 0 {
+		end(len(t))
+	}
+
+	return ret, nil
+}
+
+// unquote removes the unescaped quotes from s and the backslashes that escape
+// quotes and backslashes
+func unquote(s string) string {
+	if !strings.ContainsAny(s, `"'\`) {
+		return s
+	}
+
+	var (
+		b                strings.Builder
+		inString, inChar bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+
+		switch {
+		case ch == '\\' && i+1 < len(s) && strings.IndexByte(`\"'`, s[i+1]) >= 0:
+			i++
+			b.WriteByte(s[i])
+			continue
+		case ch == '"' && !inChar:
+			inString = !inString
+			continue
+		case ch == '\'' && !inString:
+			inChar = !inChar
+			continue
+		}
+
+		b.WriteByte(ch)
+	}
+
+	return b.String()
+}
+
+// bindFlags lists the flags each of the binding commands accept. Flags ending
+// in '=' take a value.
+var bindFlags = map[string][]string{
+	"bindsym":     {"--whole-window", "--border", "--exclude-titlebar", "--release", "--locked", "--to-code", "--input-device=", "--no-warn", "--no-repeat", "--inhibited"},
+	"bindcode":    {"--whole-window", "--border", "--exclude-titlebar", "--release", "--locked", "--input-device=", "--no-warn", "--no-repeat", "--inhibited"},
+	"bindswitch":  {"--locked", "--no-warn", "--reload"},
+	"bindgesture": {"--exact", "--input-device=", "--no-warn"},
+}
+
+func init() {
+	for _, name := range []string{"bindsym", "bindcode", "bindswitch", "bindgesture"} {
+		bindFlags["un"+name] = bindFlags[name]
+	}
+}
+
+// check reports statements that sway would reject while reading the config
+func (p *parser) check(s *Statement, open bool) error {
+	switch s.Name {
+	case "set":
+		if len(s.Words) < 2 {
+			return &Error{Pos: s.Pos, Msg: "set requires a variable name and a value"}
+		}
+
+		if !strings.HasPrefix(s.Words[0].Value, "$") {
+			return &Error{Pos: s.Words[0].Pos, Msg: fmt.Sprintf("variable name %q must start with '$'", s.Words[0].Value)}
+		}
+	case "include":
+		if len(s.Words) == 0 {
+			return &Error{Pos: s.Pos, Msg: "include requires a path"}
+		}
+	}
+
+	flags, ok := bindFlags[s.Name]
+	if !ok {
+		return nil
+	}
+
+	n := len(s.Flags())
+	for _, w := range s.Words[:n] {
+		if !isFlag(w.Value, flags) {
+			return &Error{Pos: w.Pos, Msg: fmt.Sprintf("unknown %s flag %q", s.Name, w.Value)}
+		}
+	}
+
+	// in a block, such as "bindsym { ... }", the key combinations and commands
+	// are on the lines inside of the block
+	if open {
+		return nil
+	}
+
+	want := 2
+	if strings.HasPrefix(s.Name, "un") {
+		want = 1
+	}
+
+	if len(s.Words)-n < want {
+		return &Error{Pos: s.Pos, Msg: fmt.Sprintf("%s requires a key combination and a command", s.Name)}
+	}
+
+	return nil
+}
+
+func isFlag(s string, flags []string) bool {
+	for _, f := range flags {
+		if s == f || strings.HasSuffix(f, "=") && strings.HasPrefix(s, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// include parses the files matched by the include statement s. Like sway,
+// variables, environment variables and a leading ~ are expanded in the pattern
+// and relative patterns are resolved in dir.
+func (p *parser) include(s *Statement, dir string) error {
+	if p.noIncludes {
+		return nil
+	}
+
+	pattern := os.ExpandEnv(Expand(strings.Join(s.Args(), " "), p.vars))
+
+	if pattern == "~" || strings.HasPrefix(pattern, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			pattern = home + pattern[1:]
+		}
+	}
+
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(dir, pattern)
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return &Error{Pos: s.Words[0].Pos, Msg: fmt.Sprintf("invalid include pattern %q: %v", pattern, err)}
+	}
+
+	for _, match := range matches {
+		abs, err := filepath.Abs(match)
+		if err != nil {
+			return &Error{Pos: s.Pos, Msg: err.Error()}
+		}
+
+		if p.included[abs] {
+			continue
+		}
+		p.included[abs] = true
+
+		src, err := ioutil.ReadFile(match)
+		if err != nil {
+			return &Error{Pos: s.Pos, Msg: err.Error()}
+		}
+
+		f, err := p.parse(match, string(src), filepath.Dir(match))
+		if err != nil {
+			return err
+		}
+
+		s.Includes = append(s.Includes, f)
+	}
+
+	return nil
+}
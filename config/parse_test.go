@@ -0,0 +1,196 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/joshuarubin/go-sway/config"
+)
+
+const sample = `# sample config
+set $mod Mod4
+set $term foot
+set $left h
+
+bindsym $mod+Return exec $term
+bindsym --release --to-code $mod+$left \
+    focus left
+bindcode --locked 121 exec pactl set-sink-mute @DEFAULT_SINK@ toggle
+
+mode "resize" {
+    bindsym $left resize shrink width 10px
+    bindsym Escape mode "default"
+}
+
+bar
+{
+    status_command while date; do sleep 1; done
+    colors {
+        background #323232
+    }
+}
+
+input "type:touchpad" {
+    tap enabled
+}
+`
+
+func TestParse(t *testing.T) {
+	f, err := config.Parse("config", sample, config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, s := range f.Statements {
+		names = append(names, s.Name)
+	}
+
+	want := []string{"", "set", "set", "set", "bindsym", "bindsym", "bindcode", "mode", "bar", "input"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("got statements %q, want %q", names, want)
+	}
+
+	bind := f.Statements[5]
+	if got, want := bind.Flags(), []string{"--release", "--to-code"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Flags: got %q, want %q", got, want)
+	}
+
+	if got, want := bind.Rest(3), "focus left"; got != want {
+		t.Errorf("Rest: got %q, want %q", got, want)
+	}
+
+	if got, want := bind.Words[3].Pos, (config.Pos{File: "config", Line: 8, Column: 5}); got != want {
+		t.Errorf("continued word: got position %v, want %v", got, want)
+	}
+
+	mode := f.Statements[7]
+	if !mode.IsBlock() || mode.Args()[0] != "resize" || len(mode.Body) != 2 {
+		t.Errorf("unexpected mode block %#v", mode)
+	}
+
+	bar := f.Statements[8]
+	if len(bar.Body) != 2 || bar.Body[1].Name != "colors" || len(bar.Body[1].Body) != 1 {
+		t.Errorf("unexpected bar block %#v", bar)
+	}
+
+	if got, want := bar.Body[0].Rest(0), "while date; do sleep 1; done"; got != want {
+		t.Errorf("status_command: got %q, want %q", got, want)
+	}
+
+	if got, want := f.Statements[9].Args(), []string{"type:touchpad"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("input: got %q, want %q", got, want)
+	}
+
+	vars := f.Vars()
+	if got, want := config.Expand(bind.Words[2].Value, vars), "Mod4+h"; got != want {
+		t.Errorf("Expand: got %q, want %q", got, want)
+	}
+
+	again, err := config.Parse("config", f.String(), config.WithoutIncludes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := again.String(), f.String(); got != want {
+		t.Errorf("Format is not stable:\n%s\n%s", got, want)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{"$mod": "Mod4", "$mod2": "Mod1"}
+	if got, want := config.Expand("$mod+$mod2+$other", vars), "Mod4+Mod1+$other"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for src, want := range map[string]string{
+		"bindsym $mod+a exec 'foo":           "c:1:21: unterminated quote",
+		"mode resize {\n  bindsym a b\n":     "c:1:1: mode block is never closed",
+		"output * bg x fill\n}":              "c:2:1: unexpected '}'",
+		"set mod Mod4":                       `c:1:5: variable name "mod" must start with '$'`,
+		"\n  bindsym --bogus a exec foo":     `c:2:11: unknown bindsym flag "--bogus"`,
+		"bindsym --release a":                "c:1:1: bindsym requires a key combination and a command",
+		"bindsym a \\\n  exec \"foo\\\" bar": "c:2:8: unterminated quote",
+	} {
+		_, err := config.Parse("c", src, config.WithoutIncludes())
+		if err == nil {
+			t.Errorf("%q: expected error", src)
+			continue
+		}
+
+		if _, ok := err.(*config.Error); !ok {
+			t.Errorf("%q: got %T, want *config.Error", src, err)
+		}
+
+		if err.Error() != want {
+			t.Errorf("%q: got error %q, want %q", src, err, want)
+		}
+	}
+}
+
+func TestParseInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sway-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(filepath.Join(dir, "config.d"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"config":            "set $dir config.d\ninclude $dir/*.conf\ninclude config.d/a.conf\n",
+		"config.d/a.conf":   "set $mod Mod4\n",
+		"config.d/b.conf":   "bindsym $mod+q kill\n",
+		"config.d/c.ignore": "bogus {\n",
+	}
+
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := config.ParseFile(filepath.Join(dir, "config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inc := f.Statements[1].Includes
+	if len(inc) != 2 || !strings.HasSuffix(inc[0].Name, "a.conf") || !strings.HasSuffix(inc[1].Name, "b.conf") {
+		t.Fatalf("unexpected includes %#v", inc)
+	}
+
+	if n := len(f.Statements[2].Includes); n != 0 {
+		t.Errorf("a.conf was included again")
+	}
+
+	var binds []string
+	f.Walk(func(s *config.Statement, _ []*config.Statement) bool {
+		if s.Name == "bindsym" {
+			binds = append(binds, config.Expand(s.Words[0].Value, f.Vars()))
+		}
+		return true
+	})
+
+	if want := []string{"Mod4+q"}; !reflect.DeepEqual(binds, want) {
+		t.Errorf("got bindings %q, want %q", binds, want)
+	}
+
+	// the same config without a name resolves includes in the given directory
+	f, err = config.Parse("", files["config"], config.WithDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(f.Statements[1].Includes); n != 2 {
+		t.Errorf("got %d included files, want 2", n)
+	}
+}
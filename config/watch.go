@@ -0,0 +1,104 @@
+package config
+
+import (
+	"context"
+	"sync"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+// ReloadFunc is called by a Watcher after sway reloads its config. If the new
+// config can't be loaded, err is set and the Watcher keeps comparing against
+// the last config that could be loaded.
+type ReloadFunc func(ctx context.Context, d *Diff, err error)
+
+// Watcher is an EventHandler that compares the config before and after each
+// reload. sway replaces the config before the reload event is sent, so the
+// config before the reload is the one loaded when the Watcher was created or
+// at the previous reload.
+//
+//	w, err := config.NewWatcher(ctx, client, func(ctx context.Context, d *config.Diff, err error) {
+//		...
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	return sway.Subscribe(ctx, w, sway.EventTypeWorkspace)
+type Watcher struct {
+	sway.EventHandler
+
+	client sway.Client
+	fn     ReloadFunc
+	opts   []Option
+
+	mu      sync.Mutex
+	current *File
+}
+
+// NewWatcher loads the current config from c and returns a Watcher that calls
+// fn after each reload. The options are used to parse each config, and include
+// paths are resolved as described for LoadInventory.
+func NewWatcher(ctx context.Context, c sway.Client, fn ReloadFunc, opts ...Option) (*Watcher, error) {
+	w := &Watcher{
+		EventHandler: sway.NoOpEventHandler(),
+		client:       c,
+		fn:           fn,
+		opts:         opts,
+	}
+
+	f, err := w.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w.current = f
+
+	return w, nil
+}
+
+// Watch calls fn after each reload until ctx is canceled or the connection to
+// sway fails
+func Watch(ctx context.Context, c sway.Client, fn ReloadFunc, opts ...Option) error {
+	w, err := NewWatcher(ctx, c, fn, opts...)
+	if err != nil {
+		return err
+	}
+
+	return sway.Subscribe(ctx, w, sway.EventTypeWorkspace)
+}
+
+func (w *Watcher) load(ctx context.Context) (*File, error) {
+	return loadConfig(ctx, w.client, w.opts)
+}
+
+// Config returns the config the next reload is compared against
+func (w *Watcher) Config() *File {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Workspace implements EventHandler and calls Reload for reload events
+func (w *Watcher) Workspace(ctx context.Context, e sway.WorkspaceEvent) {
+	if e.Change == sway.WorkspaceReload {
+		w.Reload(ctx)
+	}
+}
+
+// Reload loads the config from sway, compares it to the previous one and
+// passes the result to the ReloadFunc
+func (w *Watcher) Reload(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	f, err := w.load(ctx)
+	if err != nil {
+		w.fn(ctx, nil, err)
+		return
+	}
+
+	d := Compare(w.current, f)
+	w.current = f
+	w.fn(ctx, d, nil)
+}
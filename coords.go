@@ -0,0 +1,141 @@
+package sway
+
+import "math"
+
+// wlTransform returns the wl_output transform value for t
+func (t Transform) wlTransform() int {
+	switch t {
+	case Transform90:
+		return 1
+	case Transform180:
+		return 2
+	case Transform270:
+		return 3
+	case TransformFlipped:
+		return 4
+	case TransformFlipped90:
+		return 5
+	case TransformFlipped180:
+		return 6
+	case TransformFlipped270:
+		return 7
+	}
+	return 0
+}
+
+// invertTransform returns the wl_output transform that undoes t
+func invertTransform(t int) int {
+	if t&1 != 0 && t&4 == 0 {
+		t ^= 2
+	}
+	return t
+}
+
+// transformRect applies the wl_output transform t to r, which is in a space of
+// the given width and height. This is the same as wlr_box_transform.
+func transformRect(r Rect, t int, width, height int64) Rect {
+	var ret Rect
+
+	if t%2 == 0 {
+		ret.Width, ret.Height = r.Width, r.Height
+	} else {
+		ret.Width, ret.Height = r.Height, r.Width
+	}
+
+	switch t {
+	case 0:
+		ret.X, ret.Y = r.X, r.Y
+	case 1:
+		ret.X, ret.Y = height-r.Y-r.Height, r.X
+	case 2:
+		ret.X, ret.Y = width-r.X-r.Width, height-r.Y-r.Height
+	case 3:
+		ret.X, ret.Y = r.Y, width-r.X-r.Width
+	case 4:
+		ret.X, ret.Y = width-r.X-r.Width, r.Y
+	case 5:
+		ret.X, ret.Y = r.Y, r.X
+	case 6:
+		ret.X, ret.Y = r.X, height-r.Y-r.Height
+	case 7:
+		ret.X, ret.Y = height-r.Y-r.Height, width-r.X-r.Width
+	}
+
+	return ret
+}
+
+func (o Output) scale() float64 {
+	if o.Scale <= 0 {
+		return 1
+	}
+	return o.Scale
+}
+
+// scaleRect multiplies r by f, growing it to whole pixels so that the result
+// covers all of r
+func scaleRect(r Rect, f float64) Rect {
+	x0 := int64(math.Floor(float64(r.X) * f))
+	y0 := int64(math.Floor(float64(r.Y) * f))
+	x1 := int64(math.Ceil(float64(r.X+r.Width) * f))
+	y1 := int64(math.Ceil(float64(r.Y+r.Height) * f))
+	return Rect{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}
+}
+
+// physicalSize returns the size of the current mode as displayed, after
+// applying the transform
+func (o Output) physicalSize() (width, height int64) {
+	width, height = o.CurrentMode.Width, o.CurrentMode.Height
+	if o.Transform.IsRotated() {
+		width, height = height, width
+	}
+	return width, height
+}
+
+// ToPhysical converts r from global layout coordinates, as used by Node.Rect,
+// to physical pixels relative to the top left corner of the output as it is
+// displayed. The result covers every pixel touched by r.
+func (o Output) ToPhysical(r Rect) Rect {
+	return scaleRect(r.ToLocal(o.Rect), o.scale())
+}
+
+// FromPhysical converts r from physical pixels relative to the top left corner
+// of the output as it is displayed to global layout coordinates
+func (o Output) FromPhysical(r Rect) Rect {
+	return scaleRect(r, 1/o.scale()).ToGlobal(o.Rect)
+}
+
+// ToBuffer converts r from global layout coordinates to pixels in the output's
+// buffer, which is in the native orientation of the panel and has the size of
+// the current mode. It differs from ToPhysical for outputs with a transform.
+func (o Output) ToBuffer(r Rect) Rect {
+	w, h := o.physicalSize()
+	return transformRect(o.ToPhysical(r), invertTransform(o.Transform.wlTransform()), w, h)
+}
+
+// FromBuffer converts r from pixels in the output's buffer to global layout
+// coordinates
+func (o Output) FromBuffer(r Rect) Rect {
+	p := transformRect(r, o.Transform.wlTransform(), o.CurrentMode.Width, o.CurrentMode.Height)
+	return o.FromPhysical(p)
+}
+
+// PointToPhysical converts p from global layout coordinates to physical
+// pixels relative to the top left corner of the output as it is displayed
+func (o Output) PointToPhysical(p Point) Point {
+	p = p.Sub(o.Rect.Origin())
+	s := o.scale()
+	return Point{
+		X: int64(math.Floor(float64(p.X) * s)),
+		Y: int64(math.Floor(float64(p.Y) * s)),
+	}
+}
+
+// PointFromPhysical converts p from physical pixels relative to the top left
+// corner of the output as it is displayed to global layout coordinates
+func (o Output) PointFromPhysical(p Point) Point {
+	s := o.scale()
+	return Point{
+		X: int64(math.Floor(float64(p.X) / s)),
+		Y: int64(math.Floor(float64(p.Y) / s)),
+	}.Add(o.Rect.Origin())
+}
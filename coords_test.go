@@ -0,0 +1,82 @@
+package sway_test
+
+import (
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestOutputCoordinates(t *testing.T) {
+	outputs := loadOutputs(t)
+	edp, dp := outputs[0], outputs[1]
+
+	r := sway.Rect{X: 10, Y: 10, Width: 5, Height: 5}
+	if got, want := edp.ToPhysical(r), (sway.Rect{X: 20, Y: 20, Width: 10, Height: 10}); got != want {
+		t.Errorf("eDP-1 ToPhysical: got %+v, want %+v", got, want)
+	}
+
+	r = sway.Rect{X: 1920, Y: 0, Width: 100, Height: 50}
+	if got, want := dp.ToPhysical(r), (sway.Rect{Width: 100, Height: 50}); got != want {
+		t.Errorf("DP-1 ToPhysical: got %+v, want %+v", got, want)
+	}
+
+	if got, want := dp.ToBuffer(r), (sway.Rect{X: 0, Y: 1340, Width: 50, Height: 100}); got != want {
+		t.Errorf("DP-1 ToBuffer: got %+v, want %+v", got, want)
+	}
+
+	if got := dp.FromBuffer(dp.ToBuffer(r)); got != r {
+		t.Errorf("DP-1 buffer round trip: got %+v, want %+v", got, r)
+	}
+
+	if got, want := edp.PointToPhysical(sway.Point{X: 100, Y: 7}), (sway.Point{X: 200, Y: 14}); got != want {
+		t.Errorf("PointToPhysical: got %+v, want %+v", got, want)
+	}
+
+	if got, want := dp.PointFromPhysical(sway.Point{X: 5, Y: 6}), (sway.Point{X: 1925, Y: 6}); got != want {
+		t.Errorf("PointFromPhysical: got %+v, want %+v", got, want)
+	}
+
+	fractional := sway.Output{Rect: sway.Rect{Width: 1280, Height: 720}, Scale: 1.5}
+	if got, want := fractional.ToPhysical(sway.Rect{X: 1, Y: 1, Width: 1, Height: 1}), (sway.Rect{X: 1, Y: 1, Width: 2, Height: 2}); got != want {
+		t.Errorf("fractional ToPhysical: got %+v, want %+v", got, want)
+	}
+}
+
+func TestOutputTransforms(t *testing.T) {
+	for _, tr := range []sway.Transform{
+		sway.TransformNormal,
+		sway.Transform90,
+		sway.Transform180,
+		sway.Transform270,
+		sway.TransformFlipped,
+		sway.TransformFlipped90,
+		sway.TransformFlipped180,
+		sway.TransformFlipped270,
+	} {
+		o := sway.Output{
+			Rect:        sway.Rect{X: 100, Y: 200, Width: 800, Height: 600},
+			Scale:       2,
+			Transform:   tr,
+			CurrentMode: sway.OutputMode{Width: 1600, Height: 1200},
+		}
+
+		if tr.IsRotated() {
+			o.Rect.Width, o.Rect.Height = o.Rect.Height, o.Rect.Width
+		}
+
+		full := o.ToBuffer(o.Rect)
+		if want := (sway.Rect{Width: 1600, Height: 1200}); full != want {
+			t.Errorf("%s: whole output maps to %+v, want %+v", tr, full, want)
+		}
+
+		r := sway.Rect{X: 110, Y: 220, Width: 30, Height: 40}
+		buf := o.ToBuffer(r)
+		if buf.Area() != 4*r.Area() || !full.ContainsRect(buf) {
+			t.Errorf("%s: unexpected buffer rect %+v", tr, buf)
+		}
+
+		if got := o.FromBuffer(buf); got != r {
+			t.Errorf("%s: round trip got %+v, want %+v", tr, got, r)
+		}
+	}
+}
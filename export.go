@@ -0,0 +1,184 @@
+package sway
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the node hierarchy to w as a Graphviz DOT digraph. Tiling
+// children are connected with solid edges and floating children with dashed
+// edges.
+func (n *Node) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "digraph sway {")
+	fmt.Fprintln(bw, "\tnode [shape=box fontname=monospace];")
+	writeDOTNode(bw, n)
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+func writeDOTNode(w *bufio.Writer, n *Node) {
+	if n == nil {
+		return
+	}
+
+	label := fmt.Sprintf("%s #%d\n%s", n.Type, n.ID, n.Name)
+	if n.Layout != "" && n.Layout != LayoutNone {
+		label += "\n" + string(n.Layout)
+	}
+
+	if n.AppID != nil && *n.AppID != "" {
+		label += "\napp_id=" + *n.AppID
+	}
+
+	attrs := "label=" + dotQuote(label)
+	if n.Focused {
+		attrs += " style=bold"
+	}
+
+	fmt.Fprintf(w, "\tn%d [%s];\n", n.ID, attrs)
+
+	for _, child := range n.Nodes {
+		fmt.Fprintf(w, "\tn%d -> n%d;\n", n.ID, child.ID)
+		writeDOTNode(w, child)
+	}
+
+	for _, child := range n.FloatingNodes {
+		fmt.Fprintf(w, "\tn%d -> n%d [style=dashed];\n", n.ID, child.ID)
+		writeDOTNode(w, child)
+	}
+}
+
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// WriteSVG writes a standalone SVG image to w that draws every output, the
+// visible workspace on each output and the windows on it at their real
+// coordinates. Windows are drawn with their border (Rect), content
+// (WindowRect) and title bar (DecoRect).
+func (n *Node) WriteSVG(w io.Writer) error {
+	bounds := n.Rect
+	if bounds.Empty() {
+		bounds = svgBounds(n)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%d %d %d %d" width="%d" height="%d">`+"\n",
+		bounds.X, bounds.Y, bounds.Width, bounds.Height, bounds.Width, bounds.Height)
+	fmt.Fprintln(bw, `<style>text { font-family: monospace; font-size: 14px; }</style>`)
+	writeSVGNode(bw, nil, n)
+	fmt.Fprintln(bw, "</svg>")
+
+	return bw.Flush()
+}
+
+func svgBounds(n *Node) Rect {
+	var b Rect
+	n.TraverseNodes(func(n *Node) bool {
+		if n.Type == NodeOutput {
+			b = b.Union(n.Rect)
+		}
+		return false
+	})
+	return b
+}
+
+func writeSVGRect(w *bufio.Writer, r Rect, class string) {
+	if r.Empty() {
+		return
+	}
+
+	fmt.Fprintf(w, `<rect class="%s" x="%d" y="%d" width="%d" height="%d" %s/>`+"\n",
+		class, r.X, r.Y, r.Width, r.Height, svgStyles[class])
+}
+
+var svgStyles = map[string]string{
+	"output":    `fill="#f0f0f0" stroke="#1f4e9c" stroke-width="4"`,
+	"workspace": `fill="none" stroke="#2a8a3a" stroke-width="2" stroke-dasharray="8 4"`,
+	"window":    `fill="#d0d0d0" stroke="#555555" stroke-width="1"`,
+	"focused":   `fill="#ffe9a8" stroke="#c77700" stroke-width="2"`,
+	"content":   `fill="#ffffff" stroke="none"`,
+	"deco":      `fill="#8c8c8c" stroke="none"`,
+}
+
+func writeSVGText(w *bufio.Writer, r Rect, text string) {
+	fmt.Fprintf(w, `<text x="%d" y="%d">%s</text>`+"\n", r.X+4, r.Y+16, html.EscapeString(text))
+}
+
+func writeSVGNode(w *bufio.Writer, parent, n *Node) {
+	if n == nil || n.Type != NodeRoot && n.Rect.Empty() {
+		return
+	}
+
+	switch n.Type {
+	case NodeOutput:
+		writeSVGRect(w, n.Rect, "output")
+		writeSVGText(w, n.Rect, n.Name)
+	case NodeWorkspace:
+		writeSVGRect(w, n.Rect, "workspace")
+	case NodeCon, NodeFloatingCon:
+		if len(n.Nodes) == 0 && len(n.FloatingNodes) == 0 {
+			if n.Visible != nil && !*n.Visible {
+				return
+			}
+
+			class := "window"
+			if n.Focused {
+				class = "focused"
+			}
+			writeSVGRect(w, n.Rect, class)
+
+			content := n.WindowRect
+			content.X += n.Rect.X
+			content.Y += n.Rect.Y
+			writeSVGRect(w, content, "content")
+
+			if parent != nil {
+				deco := n.DecoRect
+				deco.X += parent.Rect.X
+				deco.Y += parent.Rect.Y
+				writeSVGRect(w, deco, "deco")
+			}
+
+			name := n.Name
+			if n.AppID != nil && *n.AppID != "" {
+				name = *n.AppID + ": " + name
+			}
+			if name != "" {
+				writeSVGText(w, n.Rect, name)
+			}
+		}
+	}
+
+	for _, child := range n.visibleNodes() {
+		writeSVGNode(w, n, child)
+	}
+
+	for _, child := range n.FloatingNodes {
+		writeSVGNode(w, n, child)
+	}
+}
+
+// visibleNodes returns the tiling children of n, limited to the visible
+// workspace when n is an output
+func (n *Node) visibleNodes() []*Node {
+	if n.Type != NodeOutput || len(n.Focus) == 0 {
+		return n.Nodes
+	}
+
+	for _, child := range n.Nodes {
+		if child.ID == n.Focus[0] {
+			return []*Node{child}
+		}
+	}
+
+	return n.Nodes
+}
@@ -0,0 +1,116 @@
+package sway_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func exportTree() *sway.Node {
+	visible := true
+	app := `a"b<c>`
+
+	return &sway.Node{
+		ID:   1,
+		Type: sway.NodeRoot,
+		Rect: sway.Rect{Width: 3840, Height: 1080},
+		Nodes: []*sway.Node{
+			{
+				ID:    3,
+				Name:  "DP-1",
+				Type:  sway.NodeOutput,
+				Rect:  sway.Rect{Width: 1920, Height: 1080},
+				Focus: []int64{4},
+				Nodes: []*sway.Node{
+					{
+						ID:     4,
+						Name:   "1",
+						Type:   sway.NodeWorkspace,
+						Layout: sway.LayoutSplitH,
+						Rect:   sway.Rect{Width: 1920, Height: 1080},
+						Nodes: []*sway.Node{
+							{
+								ID:         7,
+								Type:       sway.NodeCon,
+								AppID:      &app,
+								Visible:    &visible,
+								Rect:       sway.Rect{Width: 1920, Height: 1080},
+								WindowRect: sway.Rect{X: 2, Y: 2, Width: 1916, Height: 1076},
+							},
+						},
+						FloatingNodes: []*sway.Node{
+							{
+								ID:      8,
+								Type:    sway.NodeFloatingCon,
+								Visible: &visible,
+								Rect:    sway.Rect{X: 100, Y: 100, Width: 400, Height: 300},
+							},
+						},
+					},
+					{
+						ID:   5,
+						Name: "2",
+						Type: sway.NodeWorkspace,
+						Rect: sway.Rect{Width: 1920, Height: 1080},
+					},
+				},
+			},
+			{
+				ID:   6,
+				Name: "HDMI-A-1",
+				Type: sway.NodeOutput,
+				Rect: sway.Rect{X: 1920, Width: 1920, Height: 1080},
+			},
+		},
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var b bytes.Buffer
+	if err := exportTree().WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"n1 -> n3;",
+		"n4 -> n7;",
+		"n4 -> n8 [style=dashed];",
+		`app_id=a\"b<c>`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, b.String())
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	var b bytes.Buffer
+	if err := exportTree().WriteSVG(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	rects := 0
+	d := xml.NewDecoder(&b)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "rect" {
+			rects++
+		}
+	}
+
+	// 2 outputs, 1 visible workspace, 2 windows and 1 window content rect
+	if rects != 6 {
+		t.Errorf("got %d rects, want 6", rects)
+	}
+}
@@ -0,0 +1,414 @@
+package sway
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The types that sway sends record which of their fields were present in the
+// object they were decoded from. Marshaling writes those fields even if they
+// are empty, so that a round trip keeps members such as "urgent": false, and
+// leaves out the other empty fields that are tagged omitempty.
+
+// fieldSet holds a bit for each field index of a struct
+type fieldSet uint64
+
+// field is a field of a struct that is encoded as JSON
+type field struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// structFields lists the fields of a struct type that are encoded as JSON, in
+// order, and maps their lowercased names to them
+type structFields struct {
+	list   []field
+	byName map[string]field
+}
+
+// knownFields caches the fields of each struct type passed to unmarshalExtra
+// or marshalExtra
+var knownFields sync.Map // map[reflect.Type]*structFields
+
+func fieldsOf(t reflect.Type) *structFields {
+	if v, ok := knownFields.Load(t); ok {
+		return v.(*structFields)
+	}
+
+	if t.NumField() > 64 {
+		panic("sway: " + t.Name() + " has too many fields for a fieldSet")
+	}
+
+	ret := &structFields{byName: map[string]field{}}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		f := field{index: i, name: sf.Name}
+		if tag, ok := sf.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+
+			opts := strings.Split(tag, ",")
+			if opts[0] != "" {
+				f.name = opts[0]
+			}
+
+			for _, opt := range opts[1:] {
+				f.omitEmpty = f.omitEmpty || opt == "omitempty"
+			}
+		}
+
+		ret.list = append(ret.list, f)
+
+		// encoding/json matches object keys to fields case insensitively
+		ret.byName[strings.ToLower(f.name)] = f
+	}
+
+	knownFields.Store(t, ret)
+	return ret
+}
+
+// rawValue is a JSON value that refers to the data it was decoded from instead
+// of copying it
+type rawValue []byte
+
+func (r *rawValue) UnmarshalJSON(data []byte) error {
+	*r = data
+	return nil
+}
+
+// unmarshalExtra decodes data into v, which must be a pointer to a struct type
+// that does not implement json.Unmarshaler. The members of the object that
+// don't correspond to any of its fields are stored in extra, and the fields
+// that are present in sent.
+func unmarshalExtra(data []byte, v interface{}, extra *map[string]json.RawMessage, sent *fieldSet) error {
+	*extra, *sent = nil, 0
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var members map[string]rawValue
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	fields := fieldsOf(reflect.TypeOf(v).Elem())
+
+	for k, value := range members {
+		if f, ok := fields.byName[strings.ToLower(k)]; ok {
+			*sent |= 1 << uint(f.index)
+			continue
+		}
+
+		if *extra == nil {
+			*extra = map[string]json.RawMessage{}
+		}
+
+		(*extra)[k] = append(json.RawMessage(nil), value...)
+	}
+
+	return nil
+}
+
+// isEmpty returns true if omitempty leaves v out. Unlike encoding/json, structs
+// and arrays are empty if they are zero, since sway leaves out objects such as
+// idle_inhibitors rather than sending them empty.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Array, reflect.Struct:
+		return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+	}
+	return false
+}
+
+// marshalExtra encodes v, which must be a struct type that does not implement
+// json.Marshaler, followed by the members of extra that don't correspond to
+// any of its fields, sorted by key. Empty fields tagged omitempty are only
+// written if they are in sent.
+func marshalExtra(v interface{}, extra map[string]json.RawMessage, sent fieldSet) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	fields := fieldsOf(rv.Type())
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	write := func(key string, value []byte) error {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		if len(value) == 0 {
+			value = []byte("null")
+		}
+
+		buf.Write(value)
+		return nil
+	}
+
+	for _, f := range fields.list {
+		fv := rv.Field(f.index)
+		if f.omitEmpty && sent&(1<<uint(f.index)) == 0 && isEmpty(fv) {
+			continue
+		}
+
+		value, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		if err = write(f.name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		if _, ok := fields.byName[strings.ToLower(k)]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := write(k, extra[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (n *Node) UnmarshalJSON(data []byte) error {
+	type node Node
+	return unmarshalExtra(data, (*node)(n), &n.Extra, &n.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (n Node) MarshalJSON() ([]byte, error) {
+	type node Node
+	return marshalExtra(node(n), n.Extra, n.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (w *Workspace) UnmarshalJSON(data []byte) error {
+	type workspace Workspace
+	return unmarshalExtra(data, (*workspace)(w), &w.Extra, &w.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (w Workspace) MarshalJSON() ([]byte, error) {
+	type workspace Workspace
+	return marshalExtra(workspace(w), w.Extra, w.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (o *Output) UnmarshalJSON(data []byte) error {
+	type output Output
+	return unmarshalExtra(data, (*output)(o), &o.Extra, &o.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (o Output) MarshalJSON() ([]byte, error) {
+	type output Output
+	return marshalExtra(output(o), o.Extra, o.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (b *BarConfig) UnmarshalJSON(data []byte) error {
+	type barConfig BarConfig
+	return unmarshalExtra(data, (*barConfig)(b), &b.Extra, &b.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (b BarConfig) MarshalJSON() ([]byte, error) {
+	type barConfig BarConfig
+	return marshalExtra(barConfig(b), b.Extra, b.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (v *Version) UnmarshalJSON(data []byte) error {
+	type version Version
+	return unmarshalExtra(data, (*version)(v), &v.Extra, &v.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (v Version) MarshalJSON() ([]byte, error) {
+	type version Version
+	return marshalExtra(version(v), v.Extra, v.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (i *Input) UnmarshalJSON(data []byte) error {
+	type input Input
+	return unmarshalExtra(data, (*input)(i), &i.Extra, &i.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (i Input) MarshalJSON() ([]byte, error) {
+	type input Input
+	return marshalExtra(input(i), i.Extra, i.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (l *LibInput) UnmarshalJSON(data []byte) error {
+	type libInput LibInput
+	return unmarshalExtra(data, (*libInput)(l), &l.Extra, &l.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (l LibInput) MarshalJSON() ([]byte, error) {
+	type libInput LibInput
+	return marshalExtra(libInput(l), l.Extra, l.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (s *Seat) UnmarshalJSON(data []byte) error {
+	type seat Seat
+	return unmarshalExtra(data, (*seat)(s), &s.Extra, &s.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (s Seat) MarshalJSON() ([]byte, error) {
+	type seat Seat
+	return marshalExtra(seat(s), s.Extra, s.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (w *WorkspaceEvent) UnmarshalJSON(data []byte) error {
+	type workspaceEvent WorkspaceEvent
+	return unmarshalExtra(data, (*workspaceEvent)(w), &w.Extra, &w.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (w WorkspaceEvent) MarshalJSON() ([]byte, error) {
+	type workspaceEvent WorkspaceEvent
+	return marshalExtra(workspaceEvent(w), w.Extra, w.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (o *OutputEvent) UnmarshalJSON(data []byte) error {
+	type outputEvent OutputEvent
+	return unmarshalExtra(data, (*outputEvent)(o), &o.Extra, &o.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (o OutputEvent) MarshalJSON() ([]byte, error) {
+	type outputEvent OutputEvent
+	return marshalExtra(outputEvent(o), o.Extra, o.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (w *WindowEvent) UnmarshalJSON(data []byte) error {
+	type windowEvent WindowEvent
+	return unmarshalExtra(data, (*windowEvent)(w), &w.Extra, &w.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (w WindowEvent) MarshalJSON() ([]byte, error) {
+	type windowEvent WindowEvent
+	return marshalExtra(windowEvent(w), w.Extra, w.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (s *ShutdownEvent) UnmarshalJSON(data []byte) error {
+	type shutdownEvent ShutdownEvent
+	return unmarshalExtra(data, (*shutdownEvent)(s), &s.Extra, &s.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (s ShutdownEvent) MarshalJSON() ([]byte, error) {
+	type shutdownEvent ShutdownEvent
+	return marshalExtra(shutdownEvent(s), s.Extra, s.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (m *ModeEvent) UnmarshalJSON(data []byte) error {
+	type modeEvent ModeEvent
+	return unmarshalExtra(data, (*modeEvent)(m), &m.Extra, &m.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (m ModeEvent) MarshalJSON() ([]byte, error) {
+	type modeEvent ModeEvent
+	return marshalExtra(modeEvent(m), m.Extra, m.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (b *BindingEvent) UnmarshalJSON(data []byte) error {
+	type bindingEvent BindingEvent
+	return unmarshalExtra(data, (*bindingEvent)(b), &b.Extra, &b.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (b BindingEvent) MarshalJSON() ([]byte, error) {
+	type bindingEvent BindingEvent
+	return marshalExtra(bindingEvent(b), b.Extra, b.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (t *TickEvent) UnmarshalJSON(data []byte) error {
+	type tickEvent TickEvent
+	return unmarshalExtra(data, (*tickEvent)(t), &t.Extra, &t.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (t TickEvent) MarshalJSON() ([]byte, error) {
+	type tickEvent TickEvent
+	return marshalExtra(tickEvent(t), t.Extra, t.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (b *BarStateUpdateEvent) UnmarshalJSON(data []byte) error {
+	type barStateUpdateEvent BarStateUpdateEvent
+	return unmarshalExtra(data, (*barStateUpdateEvent)(b), &b.Extra, &b.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (b BarStateUpdateEvent) MarshalJSON() ([]byte, error) {
+	type barStateUpdateEvent BarStateUpdateEvent
+	return marshalExtra(barStateUpdateEvent(b), b.Extra, b.sent)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and stores unknown fields in Extra
+func (i *InputEvent) UnmarshalJSON(data []byte) error {
+	type inputEvent InputEvent
+	return unmarshalExtra(data, (*inputEvent)(i), &i.Extra, &i.sent)
+}
+
+// MarshalJSON implements json.Marshaler and includes the fields in Extra
+func (i InputEvent) MarshalJSON() ([]byte, error) {
+	type inputEvent InputEvent
+	return marshalExtra(inputEvent(i), i.Extra, i.sent)
+}
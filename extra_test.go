@@ -0,0 +1,163 @@
+package sway_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestExtraFields(t *testing.T) {
+	const data = `{"id":1,"type":"root","future_flag":true,"nodes":[{"id":2,"type":"output","future_object":{"a":[1,2]}}]}`
+
+	var n sway.Node
+	if err := json.Unmarshal([]byte(data), &n); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := string(n.Extra["future_flag"]); got != "true" {
+		t.Errorf("root future_flag: got %q", got)
+	}
+
+	if _, ok := n.Extra["nodes"]; ok {
+		t.Error("modeled field stored in Extra")
+	}
+
+	if got := string(n.Nodes[0].Extra["future_object"]); got != `{"a":[1,2]}` {
+		t.Errorf("child future_object: got %q", got)
+	}
+
+	out, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var again sway.Node
+	if err = json.Unmarshal(out, &again); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(again.Extra["future_flag"]) != "true" || string(again.Nodes[0].Extra["future_object"]) != `{"a":[1,2]}` {
+		t.Errorf("round trip lost extra fields: %s", out)
+	}
+
+	var e sway.WindowEvent
+	if err = json.Unmarshal([]byte(`{"change":"focus","container":{"id":3,"new":1},"new":"x"}`), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(e.Extra["new"]) != `"x"` || string(e.Container.Extra["new"]) != "1" {
+		t.Errorf("unexpected extra fields: %v %v", e.Extra, e.Container.Extra)
+	}
+
+	if out, err = json.Marshal(sway.TickEvent{}); err != nil || string(out) != "{}" {
+		t.Errorf("empty event: got %s, %v", out, err)
+	}
+}
+
+func TestExtraRoundTrip(t *testing.T) {
+	for _, version := range swayVersions {
+		for name, v := range map[string]func() interface{}{
+			"get_tree.json":       func() interface{} { return new(sway.Node) },
+			"get_workspaces.json": func() interface{} { return new([]sway.Workspace) },
+			"get_outputs.json":    func() interface{} { return new([]sway.Output) },
+			"get_inputs.json":     func() interface{} { return new([]sway.Input) },
+		} {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", version, name))
+			if err != nil {
+				continue
+			}
+
+			v := v()
+			if err = json.Unmarshal(data, v); err != nil {
+				t.Fatalf("%s/%s: %v", version, name, err)
+			}
+
+			out, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", version, name, err)
+			}
+
+			// members can be reordered and numbers formatted differently, but
+			// none are added or dropped
+			var got, want interface{}
+			if err = json.Unmarshal(out, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = json.Unmarshal(data, &want); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				diffJSON(t, version+"/"+name, got, want)
+			}
+		}
+	}
+}
+
+func TestExtraChanged(t *testing.T) {
+	var ws sway.Workspace
+	if err := json.Unmarshal([]byte(`{"num":0,"name":"0","urgent":false,"percent":null,"future":1}`), &ws); err != nil {
+		t.Fatal(err)
+	}
+
+	ws.Name = "1"
+	ws.Num = 1
+	ws.Focused = true
+	delete(ws.Extra, "future")
+	ws.Extra["next"] = json.RawMessage(`2`)
+
+	out, err := json.Marshal(ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fields are written in order, including the empty ones that sway
+	// sent, followed by the members of Extra
+	if want := `{"num":1,"name":"1","focused":true,"urgent":false,"next":2,"percent":null}`; string(out) != want {
+		t.Errorf("got  %s\nwant %s", out, want)
+	}
+}
+
+// diffJSON reports the paths at which the decoded JSON values got and want
+// differ
+func diffJSON(t *testing.T, path string, got, want interface{}) {
+	t.Helper()
+
+	g, gok := got.(map[string]interface{})
+	w, wok := want.(map[string]interface{})
+	if gok && wok {
+		for k := range w {
+			if _, ok := g[k]; !ok {
+				t.Errorf("%s.%s: missing", path, k)
+			}
+		}
+
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				t.Errorf("%s.%s: added", path, k)
+				continue
+			}
+			diffJSON(t, path+"."+k, g[k], w[k])
+		}
+		return
+	}
+
+	ga, gok := got.([]interface{})
+	wa, wok := want.([]interface{})
+	if gok && wok && len(ga) == len(wa) {
+		for i := range ga {
+			diffJSON(t, fmt.Sprintf("%s[%d]", path, i), ga[i], wa[i])
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("%s: got %v, want %v", path, got, want)
+	}
+}
@@ -0,0 +1,202 @@
+package sway_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+const (
+	ipcRunCommand    = 0
+	ipcGetWorkspaces = 1
+	ipcSubscribe     = 2
+	ipcGetOutputs    = 3
+	ipcGetTree       = 4
+	ipcGetInputs     = 100
+
+	ipcEventWorkspace = 0x80000000
+	ipcEventOutput    = 0x80000001
+	ipcEventInput     = 0x80000015
+)
+
+type ipcHeader struct {
+	Magic  [6]byte
+	Length uint32
+	Type   uint32
+}
+
+// fakeSway is a minimal sway IPC server. The handler is called for each
+// message and returns the payload of the reply.
+type fakeSway struct {
+	path string
+	ln   net.Listener
+
+	mu       sync.Mutex
+	commands []string
+	subs     []net.Conn
+}
+
+func newFakeSway(t *testing.T, handler func(f *fakeSway, typ uint32, payload string) string) *fakeSway {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "fakesway")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fakeSway{path: filepath.Join(dir, "sway.sock")}
+
+	if f.ln, err = net.Listen("unix", f.path); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = f.ln.Close()
+		_ = os.RemoveAll(dir)
+	})
+
+	go func() {
+		for {
+			conn, err := f.ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go f.serve(conn, handler)
+		}
+	}()
+
+	return f
+}
+
+func (f *fakeSway) serve(conn net.Conn, handler func(f *fakeSway, typ uint32, payload string) string) {
+	defer conn.Close()
+
+	for {
+		var h ipcHeader
+		if err := binary.Read(conn, binary.LittleEndian, &h); err != nil {
+			return
+		}
+
+		payload := make([]byte, h.Length)
+		if _, err := io.ReadFull(conn, payload); err != nil {
+			return
+		}
+
+		if h.Type == ipcRunCommand {
+			f.mu.Lock()
+			f.commands = append(f.commands, string(payload))
+			f.mu.Unlock()
+		}
+
+		reply := handler(f, h.Type, string(payload))
+
+		// subscribers are registered along with the reply so that they get the
+		// events caused by any later message, but no event is sent before it
+		if h.Type == ipcSubscribe {
+			f.mu.Lock()
+			f.subs = append(f.subs, conn)
+			err := f.write(conn, h.Type, reply)
+			f.mu.Unlock()
+
+			if err != nil {
+				return
+			}
+
+			continue
+		}
+
+		if err := f.write(conn, h.Type, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeSway) write(conn net.Conn, typ uint32, payload string) error {
+	h := ipcHeader{Length: uint32(len(payload)), Type: typ}
+	copy(h.Magic[:], "i3-ipc")
+
+	if err := binary.Write(conn, binary.LittleEndian, &h); err != nil {
+		return err
+	}
+
+	_, err := conn.Write([]byte(payload))
+	return err
+}
+
+// Event sends an event to the connections that subscribed to any events
+func (f *fakeSway) Event(typ uint32, payload string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, conn := range f.subs {
+		_ = f.write(conn, typ, payload)
+	}
+}
+
+func (f *fakeSway) Commands() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.commands...)
+}
+
+func (f *fakeSway) client(ctx context.Context, t *testing.T) sway.Client {
+	t.Helper()
+
+	c, err := sway.New(ctx, sway.WithSocketPath(f.path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// subscribe runs sway.Subscribe with h against f until ctx is canceled. It
+// returns once the subscription is registered, so that f.Event reaches h.
+func (f *fakeSway) subscribe(ctx context.Context, t *testing.T, h sway.EventHandler, events ...sway.EventType) {
+	t.Helper()
+
+	f.mu.Lock()
+	n := len(f.subs)
+	f.mu.Unlock()
+
+	sock, ok := os.LookupEnv("SWAYSOCK")
+	if err := os.Setenv("SWAYSOCK", f.path); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv("SWAYSOCK", sock)
+		} else {
+			_ = os.Unsetenv("SWAYSOCK")
+		}
+	})
+
+	go func() {
+		_ = sway.Subscribe(ctx, h, events...)
+	}()
+
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		f.mu.Lock()
+		done := len(f.subs) > n
+		f.mu.Unlock()
+
+		if done {
+			return
+		}
+
+		if time.Since(start) > time.Second {
+			t.Fatal("timed out waiting for the subscription")
+		}
+	}
+}
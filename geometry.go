@@ -0,0 +1,189 @@
+package sway
+
+import "math"
+
+// Point is a position in the global layout coordinate space sway uses for Rect
+type Point struct {
+	X int64 `json:"x"`
+	Y int64 `json:"y"`
+}
+
+// Add returns p translated by q
+func (p Point) Add(q Point) Point {
+	return Point{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+// Sub returns p translated by -q
+func (p Point) Sub(q Point) Point {
+	return Point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+// Origin returns the top left corner of r
+func (r Rect) Origin() Point {
+	return Point{X: r.X, Y: r.Y}
+}
+
+// Empty returns true if r has no area
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Area returns the area of r
+func (r Rect) Area() int64 {
+	if r.Empty() {
+		return 0
+	}
+	return r.Width * r.Height
+}
+
+// Center returns the center of r, rounded down
+func (r Rect) Center() Point {
+	return Point{X: r.X + r.Width/2, Y: r.Y + r.Height/2}
+}
+
+// Contains returns true if p is inside of r. The right and bottom edges are
+// not considered inside, so adjacent rects never both contain a point.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.X && p.X < r.X+r.Width &&
+		p.Y >= r.Y && p.Y < r.Y+r.Height
+}
+
+// ContainsRect returns true if s is entirely inside of r
+func (r Rect) ContainsRect(s Rect) bool {
+	if s.Empty() {
+		return false
+	}
+
+	return s.X >= r.X && s.X+s.Width <= r.X+r.Width &&
+		s.Y >= r.Y && s.Y+s.Height <= r.Y+r.Height
+}
+
+// Intersects returns true if r and s overlap. Rects that only share an edge do
+// not intersect.
+func (r Rect) Intersects(s Rect) bool {
+	return !r.Intersection(s).Empty()
+}
+
+// Intersection returns the largest rect contained by both r and s. If they do
+// not intersect, the zero Rect is returned.
+func (r Rect) Intersection(s Rect) Rect {
+	x0, y0 := max64(r.X, s.X), max64(r.Y, s.Y)
+	x1 := min64(r.X+r.Width, s.X+s.Width)
+	y1 := min64(r.Y+r.Height, s.Y+s.Height)
+
+	if x1 <= x0 || y1 <= y0 {
+		return Rect{}
+	}
+
+	return Rect{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}
+}
+
+// Union returns the smallest rect that contains both r and s. Empty rects are
+// ignored.
+func (r Rect) Union(s Rect) Rect {
+	if r.Empty() {
+		return s
+	}
+
+	if s.Empty() {
+		return r
+	}
+
+	x0, y0 := min64(r.X, s.X), min64(r.Y, s.Y)
+	x1 := max64(r.X+r.Width, s.X+s.Width)
+	y1 := max64(r.Y+r.Height, s.Y+s.Height)
+
+	return Rect{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}
+}
+
+// Translate returns r moved by dx and dy
+func (r Rect) Translate(dx, dy int64) Rect {
+	r.X += dx
+	r.Y += dy
+	return r
+}
+
+// Distance returns the distance from p to the closest edge of r, or 0 if p is
+// inside of r
+func (r Rect) Distance(p Point) float64 {
+	dx := max64(max64(r.X-p.X, 0), p.X-(r.X+r.Width))
+	dy := max64(max64(r.Y-p.Y, 0), p.Y-(r.Y+r.Height))
+	return math.Hypot(float64(dx), float64(dy))
+}
+
+// DistanceTo returns the distance between the closest edges of r and s, or 0
+// if they intersect or touch
+func (r Rect) DistanceTo(s Rect) float64 {
+	dx := max64(max64(r.X-(s.X+s.Width), 0), s.X-(r.X+r.Width))
+	dy := max64(max64(r.Y-(s.Y+s.Height), 0), s.Y-(r.Y+r.Height))
+	return math.Hypot(float64(dx), float64(dy))
+}
+
+// ToLocal converts r from global coordinates to coordinates relative to the
+// origin of the parent rect, such as an output's Rect
+func (r Rect) ToLocal(parent Rect) Rect {
+	return r.Translate(-parent.X, -parent.Y)
+}
+
+// ToGlobal converts r from coordinates relative to the origin of the parent
+// rect, such as an output's Rect, to global coordinates
+func (r Rect) ToGlobal(parent Rect) Rect {
+	return r.Translate(parent.X, parent.Y)
+}
+
+// OutputAt returns the active output that contains p or nil if there is none
+func OutputAt(outputs []Output, p Point) *Output {
+	for i := range outputs {
+		if outputs[i].Active && outputs[i].Rect.Contains(p) {
+			return &outputs[i]
+		}
+	}
+	return nil
+}
+
+// OutputFor returns the active output that contains the largest part of r. If
+// r does not intersect any output, the closest one is returned. It returns nil
+// if there are no active outputs.
+func OutputFor(outputs []Output, r Rect) *Output {
+	var (
+		ret      *Output
+		bestArea int64
+		bestDist = math.Inf(1)
+	)
+
+	for i := range outputs {
+		o := &outputs[i]
+		if !o.Active {
+			continue
+		}
+
+		if area := o.Rect.Intersection(r).Area(); area > bestArea {
+			ret, bestArea = o, area
+			continue
+		}
+
+		if bestArea > 0 {
+			continue
+		}
+
+		if d := o.Rect.DistanceTo(r); d < bestDist {
+			ret, bestDist = o, d
+		}
+	}
+
+	return ret
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
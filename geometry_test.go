@@ -0,0 +1,105 @@
+package sway_test
+
+import (
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestRect(t *testing.T) {
+	a := sway.Rect{X: 0, Y: 0, Width: 100, Height: 50}
+	b := sway.Rect{X: 50, Y: 25, Width: 100, Height: 50}
+	c := sway.Rect{X: 100, Y: 0, Width: 10, Height: 10}
+
+	if got, want := a.Intersection(b), (sway.Rect{X: 50, Y: 25, Width: 50, Height: 25}); got != want {
+		t.Errorf("Intersection: got %+v, want %+v", got, want)
+	}
+
+	if a.Intersects(c) {
+		t.Error("rects sharing an edge should not intersect")
+	}
+
+	if got, want := a.Union(b), (sway.Rect{Width: 150, Height: 75}); got != want {
+		t.Errorf("Union: got %+v, want %+v", got, want)
+	}
+
+	if got := a.Union(sway.Rect{}); got != a {
+		t.Errorf("Union with empty rect: got %+v", got)
+	}
+
+	if got, want := a.Center(), (sway.Point{X: 50, Y: 25}); got != want {
+		t.Errorf("Center: got %+v, want %+v", got, want)
+	}
+
+	if a.Area() != 5000 || (sway.Rect{Width: -1, Height: 5}).Area() != 0 {
+		t.Error("unexpected Area")
+	}
+
+	if !a.Contains(sway.Point{X: 99, Y: 49}) || a.Contains(sway.Point{X: 100, Y: 0}) {
+		t.Error("unexpected Contains")
+	}
+
+	if !a.ContainsRect(sway.Rect{X: 10, Y: 10, Width: 90, Height: 40}) || a.ContainsRect(b) {
+		t.Error("unexpected ContainsRect")
+	}
+
+	if got := a.Distance(sway.Point{X: 103, Y: 54}); got != 5 {
+		t.Errorf("Distance: got %v", got)
+	}
+
+	if got := a.DistanceTo(sway.Rect{X: 0, Y: 60, Width: 10, Height: 10}); got != 10 {
+		t.Errorf("DistanceTo: got %v", got)
+	}
+
+	output := sway.Rect{X: 1920, Y: 0, Width: 1440, Height: 2560}
+	local := sway.Rect{X: 10, Y: 20, Width: 30, Height: 40}
+	if got := local.ToGlobal(output); got != local.Translate(1920, 0) || got.ToLocal(output) != local {
+		t.Errorf("unexpected ToGlobal/ToLocal: %+v", got)
+	}
+}
+
+func TestOutputAt(t *testing.T) {
+	var outputs []sway.Output
+	if !loadFixture(t, "1.10", "get_outputs.json", &outputs) {
+		t.Fatal("missing fixture")
+	}
+
+	for _, tc := range []struct {
+		p    sway.Point
+		want string
+	}{
+		{sway.Point{X: 0, Y: 0}, "eDP-1"},
+		{sway.Point{X: 1919, Y: 1079}, "eDP-1"},
+		{sway.Point{X: 1920, Y: 0}, "DP-1"},
+		{sway.Point{X: 2000, Y: 2000}, "DP-1"},
+		{sway.Point{X: 100, Y: 2000}, ""},
+	} {
+		got := ""
+		if o := sway.OutputAt(outputs, tc.p); o != nil {
+			got = o.Name
+		}
+
+		if got != tc.want {
+			t.Errorf("OutputAt(%+v): got %q, want %q", tc.p, got, tc.want)
+		}
+	}
+
+	for _, tc := range []struct {
+		r    sway.Rect
+		want string
+	}{
+		{sway.Rect{X: 1800, Y: 0, Width: 200, Height: 100}, "eDP-1"},
+		{sway.Rect{X: 1800, Y: 0, Width: 300, Height: 100}, "DP-1"},
+		{sway.Rect{X: 0, Y: 1200, Width: 100, Height: 100}, "eDP-1"},
+		{sway.Rect{X: 1700, Y: 1500, Width: 100, Height: 100}, "DP-1"},
+	} {
+		got := ""
+		if o := sway.OutputFor(outputs, tc.r); o != nil {
+			got = o.Name
+		}
+
+		if got != tc.want {
+			t.Errorf("OutputFor(%+v): got %q, want %q", tc.r, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,511 @@
+package sway
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshuarubin/go-sway/command"
+	"go.uber.org/multierr"
+)
+
+// the changes of the input events that confirm input settings
+const (
+	inputChangeLibInput  = "libinput_config"
+	inputChangeXKBLayout = "xkb_layout"
+	inputChangeXKBKeymap = "xkb_keymap"
+)
+
+// inputEventTimeout is how long ConfigureInput waits for the input events that
+// confirm the settings, unless ctx has an earlier deadline
+const inputEventTimeout = time.Second
+
+// InputOption is a setting passed to ConfigureInput
+type InputOption func(*inputConfig)
+
+type inputConfig struct {
+	settings []inputSetting
+	err      error
+}
+
+// inputSetting is a single input command, e.g. "tap enabled"
+type inputSetting struct {
+	args []string
+
+	// change is the change of the input event sent when the setting changes
+	change string
+
+	// supports returns true if the device has the setting
+	supports func(i *Input) bool
+
+	// check returns an error if the setting is not in effect
+	check func(i *Input) error
+
+	// unverified is set if check can't tell whether the setting is already in
+	// effect. The setting is always sent.
+	unverified bool
+}
+
+func (c *inputConfig) add(s inputSetting) {
+	c.settings = append(c.settings, s)
+}
+
+func (c *inputConfig) invalid(format string, a ...interface{}) {
+	c.err = multierr.Append(c.err, fmt.Errorf(format, a...))
+}
+
+func libInputState(enabled bool) string {
+	if enabled {
+		return string(LibInputEnabled)
+	}
+	return string(LibInputDisabled)
+}
+
+// libInputOption returns an option for a libinput setting that is reported by
+// sway as a string. Devices that don't have the setting don't report it.
+func libInputOption(name, value string, get func(*LibInput) string) InputOption {
+	return func(c *inputConfig) {
+		c.add(inputSetting{
+			args:   []string{name, value},
+			change: inputChangeLibInput,
+			supports: func(i *Input) bool {
+				return i.LibInput != nil && get(i.LibInput) != ""
+			},
+			check: func(i *Input) error {
+				if got := get(i.LibInput); got != value {
+					return fmt.Errorf("%s is %s, want %s", name, got, value)
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// WithInputSendEvents sets whether the device sends events
+func WithInputSendEvents(mode SendEvents) InputOption {
+	if !mode.IsKnown() {
+		return func(c *inputConfig) { c.invalid("invalid send_events mode %q", mode) }
+	}
+
+	return libInputOption("events", string(mode), func(l *LibInput) string { return string(l.SendEvents) })
+}
+
+// WithInputTap enables or disables tap to click
+func WithInputTap(enabled bool) InputOption {
+	return libInputOption("tap", libInputState(enabled), func(l *LibInput) string { return string(l.Tap) })
+}
+
+// WithInputTapButtonMap sets the finger to button mapping for tapping
+func WithInputTapButtonMap(m ButtonMap) InputOption {
+	if !m.IsKnown() {
+		return func(c *inputConfig) { c.invalid("invalid tap_button_map %q", m) }
+	}
+
+	return libInputOption("tap_button_map", string(m), func(l *LibInput) string { return string(l.TapButtonMap) })
+}
+
+// WithInputTapDrag enables or disables tap-and-drag
+func WithInputTapDrag(enabled bool) InputOption {
+	return libInputOption("drag", libInputState(enabled), func(l *LibInput) string { return string(l.TapDrag) })
+}
+
+// WithInputTapDragLock enables or disables drag lock
+func WithInputTapDragLock(enabled bool) InputOption {
+	return libInputOption("drag_lock", libInputState(enabled), func(l *LibInput) string { return string(l.TapDragLock) })
+}
+
+// WithInputAccelSpeed sets the pointer acceleration, between -1 and 1
+func WithInputAccelSpeed(speed float64) InputOption {
+	return func(c *inputConfig) {
+		if speed < -1 || speed > 1 {
+			c.invalid("invalid accel speed %v", speed)
+			return
+		}
+
+		c.add(inputSetting{
+			args:   []string{"pointer_accel", strconv.FormatFloat(speed, 'f', -1, 64)},
+			change: inputChangeLibInput,
+			supports: func(i *Input) bool {
+				// accel_speed is reported together with accel_profile, but it
+				// can't be told apart from 0 when it is missing
+				return i.LibInput != nil && i.LibInput.AccelProfile != ""
+			},
+			check: func(i *Input) error {
+				if got := i.LibInput.AccelSpeed; math.Abs(got-speed) > 1e-3 {
+					return fmt.Errorf("accel speed is %v, want %v", got, speed)
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// WithInputAccelProfile sets the acceleration profile. It has to be flat or
+// adaptive.
+func WithInputAccelProfile(p AccelProfile) InputOption {
+	if p != AccelProfileFlat && p != AccelProfileAdaptive {
+		return func(c *inputConfig) { c.invalid("invalid accel profile %q", p) }
+	}
+
+	return libInputOption("accel_profile", string(p), func(l *LibInput) string { return string(l.AccelProfile) })
+}
+
+// WithInputNaturalScroll enables or disables natural scrolling
+func WithInputNaturalScroll(enabled bool) InputOption {
+	return libInputOption("natural_scroll", libInputState(enabled), func(l *LibInput) string { return string(l.NaturalScroll) })
+}
+
+// WithInputLeftHanded enables or disables left-handed mode
+func WithInputLeftHanded(enabled bool) InputOption {
+	return libInputOption("left_handed", libInputState(enabled), func(l *LibInput) string { return string(l.LeftHanded) })
+}
+
+// WithInputClickMethod sets the click method
+func WithInputClickMethod(m ClickMethod) InputOption {
+	if !m.IsKnown() {
+		return func(c *inputConfig) { c.invalid("invalid click method %q", m) }
+	}
+
+	return libInputOption("click_method", string(m), func(l *LibInput) string { return string(l.ClickMethod) })
+}
+
+// WithInputClickButtonMap sets the finger to button mapping for the
+// clickfinger click method
+func WithInputClickButtonMap(m ButtonMap) InputOption {
+	if !m.IsKnown() {
+		return func(c *inputConfig) { c.invalid("invalid clickfinger_button_map %q", m) }
+	}
+
+	return libInputOption("clickfinger_button_map", string(m), func(l *LibInput) string { return string(l.ClickButtonMap) })
+}
+
+// WithInputMiddleEmulation enables or disables middle click emulation
+func WithInputMiddleEmulation(enabled bool) InputOption {
+	return libInputOption("middle_emulation", libInputState(enabled), func(l *LibInput) string { return string(l.MiddleEmulation) })
+}
+
+// WithInputScrollMethod sets the scroll method
+func WithInputScrollMethod(m ScrollMethod) InputOption {
+	if !m.IsKnown() {
+		return func(c *inputConfig) { c.invalid("invalid scroll method %q", m) }
+	}
+
+	return libInputOption("scroll_method", string(m), func(l *LibInput) string { return string(l.ScrollMethod) })
+}
+
+// WithInputScrollButton sets the button, as an input event code, that is used
+// for the on_button_down scroll method
+func WithInputScrollButton(code int64) InputOption {
+	return func(c *inputConfig) {
+		c.add(inputSetting{
+			args:   []string{"scroll_button", strconv.FormatInt(code, 10)},
+			change: inputChangeLibInput,
+			supports: func(i *Input) bool {
+				return i.LibInput != nil && i.LibInput.ScrollMethod != ""
+			},
+			check: func(i *Input) error {
+				if got := i.LibInput.ScrollButton; got != code {
+					return fmt.Errorf("scroll button is %d, want %d", got, code)
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// WithInputScrollButtonLock enables or disables scroll button lock
+func WithInputScrollButtonLock(enabled bool) InputOption {
+	return libInputOption("scroll_button_lock", libInputState(enabled), func(l *LibInput) string { return string(l.ScrollButtonLock) })
+}
+
+// WithInputDWT enables or disables disable-while-typing
+func WithInputDWT(enabled bool) InputOption {
+	return libInputOption("dwt", libInputState(enabled), func(l *LibInput) string { return string(l.DWT) })
+}
+
+// WithInputDWTP enables or disables disable-while-trackpointing
+func WithInputDWTP(enabled bool) InputOption {
+	return libInputOption("dwtp", libInputState(enabled), func(l *LibInput) string { return string(l.DWTP) })
+}
+
+// WithInputCalibrationMatrix sets the calibration matrix of an absolute device
+// such as a touchscreen
+func WithInputCalibrationMatrix(m [6]float64) InputOption {
+	return func(c *inputConfig) {
+		args := []string{"calibration_matrix"}
+		for _, v := range m {
+			args = append(args, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+
+		c.add(inputSetting{
+			args:   args,
+			change: inputChangeLibInput,
+			supports: func(i *Input) bool {
+				return i.LibInput != nil && i.LibInput.CalibrationMatrix != [6]float64{}
+			},
+			check: func(i *Input) error {
+				got := i.LibInput.CalibrationMatrix
+				for j := range got {
+					if math.Abs(got[j]-m[j]) > 1e-6 {
+						return fmt.Errorf("calibration matrix is %v, want %v", got, m)
+					}
+				}
+				return nil
+			},
+		})
+	}
+}
+
+func isKeyboard(i *Input) bool {
+	return i.Type == InputKeyboard
+}
+
+// WithInputXKBSwitchLayout switches keyboards to the layout at index in their
+// XKBLayoutNames
+func WithInputXKBSwitchLayout(index int64) InputOption {
+	return func(c *inputConfig) {
+		if index < 0 {
+			c.invalid("invalid layout index %d", index)
+			return
+		}
+
+		c.add(inputSetting{
+			args:   []string{"xkb_switch_layout", strconv.FormatInt(index, 10)},
+			change: inputChangeXKBLayout,
+			supports: func(i *Input) bool {
+				return isKeyboard(i) && index < int64(len(i.XKBLayoutNames))
+			},
+			check: func(i *Input) error {
+				if got := i.XKBActiveLayoutIndex; got == nil || *got != index {
+					return fmt.Errorf("active layout is not %d", index)
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// WithInputXKBLayout sets the list of layouts of keyboards, e.g. "us" and
+// "de". sway reports the layouts by their descriptions, so only the number of
+// layouts is confirmed and the layouts are always sent. The active layout is
+// reset to the first one.
+func WithInputXKBLayout(layouts ...string) InputOption {
+	return func(c *inputConfig) {
+		if len(layouts) == 0 {
+			c.invalid("no keyboard layouts")
+			return
+		}
+
+		c.add(inputSetting{
+			args: []string{"xkb_layout", strings.Join(layouts, ",")},
+			// a new layout list compiles a new keymap
+			change:   inputChangeXKBKeymap,
+			supports: isKeyboard,
+			check: func(i *Input) error {
+				if got := len(i.XKBLayoutNames); got != len(layouts) {
+					return fmt.Errorf("%d layouts are configured, want %d", got, len(layouts))
+				}
+				return nil
+			},
+			unverified: true,
+		})
+	}
+}
+
+// InputTypeIdentifier returns the identifier that configures all devices of
+// type t with ConfigureInput, e.g. "type:touchpad"
+func InputTypeIdentifier(t InputType) string {
+	return "type:" + string(t)
+}
+
+// matchesInput returns true if the identifier of an input command, a device
+// identifier, "type:<type>" or "*", applies to i
+func matchesInput(identifier string, i *Input) bool {
+	switch {
+	case identifier == "*":
+		return true
+	case strings.HasPrefix(identifier, "type:"):
+		return string(i.Type) == identifier[len("type:"):]
+	}
+	return i.Identifier == identifier
+}
+
+// ConfigureInput applies settings to the input devices matched by identifier,
+// which is the identifier of a device, the identifier of a device type such as
+// "type:keyboard" (see InputTypeIdentifier) or "*". Each setting is applied to
+// the devices that support it and it is an error if none of them do.
+//
+// The current state of the devices comes from GetInputs. If all of the settings
+// already hold, nothing is changed. Otherwise all of them are sent and
+// ConfigureInput waits for the input events that sway sends for the changed
+// devices, and then reads the devices again to confirm the settings. Settings
+// that can't be confirmed, such as WithInputXKBLayout, are always sent. The
+// devices after the change are returned, even if a setting did not take effect.
+func ConfigureInput(ctx context.Context, c Client, identifier string, opts ...InputOption) ([]Input, error) {
+	devices, err := getInputs(ctx, c, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg inputConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// pending holds the device identifiers and changes of the expected events
+	pending := map[string]bool{}
+
+	var cmds []command.Command
+	for _, s := range cfg.settings {
+		var supported bool
+		for i := range devices {
+			if !s.supports(&devices[i]) {
+				continue
+			}
+
+			supported = true
+			if s.unverified || s.check(&devices[i]) != nil {
+				pending[devices[i].Identifier+"\x00"+s.change] = true
+			}
+		}
+
+		if !supported {
+			cfg.invalid("no device supports %s", s.args[0])
+		}
+
+		cmds = append(cmds, command.Input(identifier, s.args...))
+	}
+
+	if cfg.err != nil {
+		return nil, fmt.Errorf("input %s: %v", identifier, cfg.err)
+	}
+
+	if len(pending) == 0 {
+		return devices, nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, inputEventTimeout)
+	defer cancel()
+
+	events, err := subscribeInputs(waitCtx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = runCommands(ctx, c, cmds...); err != nil {
+		return nil, err
+	}
+
+	for len(pending) > 0 && events != nil {
+		e, ok := <-events
+		if !ok {
+			// timed out, whatever changed is read below
+			break
+		}
+
+		key := e.Input.Identifier + "\x00" + e.Change
+		if !pending[key] {
+			continue
+		}
+
+		// sway sends an event for each setting, wait until all of them are
+		// in effect
+		done := true
+		for _, s := range cfg.settings {
+			if s.change == e.Change && s.supports(&e.Input) && s.check(&e.Input) != nil {
+				done = false
+			}
+		}
+
+		if done {
+			delete(pending, key)
+		}
+	}
+
+	if devices, err = getInputs(ctx, c, identifier); err != nil {
+		return nil, err
+	}
+
+	var errs error
+	for i := range devices {
+		for _, s := range cfg.settings {
+			if !s.supports(&devices[i]) {
+				continue
+			}
+
+			if err := s.check(&devices[i]); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("%s: %v", devices[i].Identifier, err))
+			}
+		}
+	}
+
+	if errs != nil {
+		return devices, fmt.Errorf("input %s was not configured: %v", identifier, errs)
+	}
+
+	return devices, nil
+}
+
+func getInputs(ctx context.Context, c Client, identifier string) ([]Input, error) {
+	inputs, err := c.GetInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []Input
+	for i := range inputs {
+		if matchesInput(identifier, &inputs[i]) {
+			ret = append(ret, inputs[i])
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("input %s not found", identifier)
+	}
+
+	return ret, nil
+}
+
+// subscribeInputs subscribes to input events on a new connection to the same
+// socket as c. The events are sent on the returned channel, which is closed
+// when ctx is done. If c was not created by New, the returned channel is nil.
+func subscribeInputs(ctx context.Context, c Client) (<-chan InputEvent, error) {
+	if _, ok := c.(*client); !ok {
+		return nil, nil
+	}
+
+	sub, err := newSubscription(ctx, c, EventTypeInput)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan InputEvent)
+
+	go func() {
+		defer close(events)
+		defer sub.conn.Close()
+
+		for {
+			msg, err := sub.recvMsg(ctx)
+			if err != nil {
+				return
+			}
+
+			var e InputEvent
+			if err = msg.Decode(&e); err != nil {
+				continue
+			}
+
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
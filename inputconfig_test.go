@@ -0,0 +1,195 @@
+package sway_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+const (
+	keyboardInput = `{"identifier": "1:1:AT_Translated_Set_2_keyboard", "type": "keyboard",
+		"xkb_layout_names": ["English (US)", "German"], "xkb_active_layout_index": 0,
+		"libinput": {"send_events": "enabled"}}`
+
+	touchpadBefore = `{"identifier": "1739:52619:SYNA8004:00_06CB:CD8B_Touchpad", "type": "touchpad",
+		"libinput": {"send_events": "enabled", "tap": "disabled", "natural_scroll": "disabled", "dwt": "enabled"}}`
+
+	touchpadTap = `{"identifier": "1739:52619:SYNA8004:00_06CB:CD8B_Touchpad", "type": "touchpad",
+		"libinput": {"send_events": "enabled", "tap": "enabled", "natural_scroll": "disabled", "dwt": "enabled"}}`
+
+	touchpadAfter = `{"identifier": "1739:52619:SYNA8004:00_06CB:CD8B_Touchpad", "type": "touchpad",
+		"libinput": {"send_events": "enabled", "tap": "enabled", "natural_scroll": "enabled", "dwt": "enabled"}}`
+)
+
+func newInputSway(t *testing.T) *fakeSway {
+	var (
+		mu       sync.Mutex
+		touchpad = touchpadBefore
+	)
+
+	return newFakeSway(t, func(f *fakeSway, typ uint32, payload string) string {
+		switch typ {
+		case ipcSubscribe:
+			return `{"success": true}`
+		case ipcRunCommand:
+			mu.Lock()
+			touchpad = touchpadAfter
+			mu.Unlock()
+
+			// like sway, send an event for each setting that changed
+			f.Event(ipcEventInput, `{"change": "libinput_config", "input": `+touchpadTap+`}`)
+			f.Event(ipcEventInput, `{"change": "libinput_config", "input": `+touchpadAfter+`}`)
+
+			n := len(strings.Split(payload, ";"))
+			return "[" + strings.TrimSuffix(strings.Repeat(`{"success":true},`, n), ",") + "]"
+		case ipcGetInputs:
+			mu.Lock()
+			defer mu.Unlock()
+			return "[" + keyboardInput + "," + touchpad + "]"
+		}
+		return "[]"
+	})
+}
+
+func TestConfigureInput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newInputSway(t)
+	c := f.client(ctx, t)
+
+	start := time.Now()
+
+	devices, err := sway.ConfigureInput(ctx, c, sway.InputTypeIdentifier(sway.InputTouchpad),
+		sway.WithInputTap(true),
+		sway.WithInputNaturalScroll(true),
+		sway.WithInputDWT(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the events confirm the settings, so there is no need to wait for the
+	// timeout
+	if d := time.Since(start); d >= time.Second {
+		t.Errorf("took %v", d)
+	}
+
+	if len(devices) != 1 || devices[0].LibInput.NaturalScroll != sway.LibInputEnabled {
+		t.Errorf("unexpected devices %+v", devices)
+	}
+
+	want := "input type:touchpad tap enabled; input type:touchpad natural_scroll enabled; input type:touchpad dwt enabled"
+	if got := f.Commands(); len(got) != 1 || got[0] != want {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+
+	// nothing changes, so nothing is sent
+	if _, err = sway.ConfigureInput(ctx, c, "*", sway.WithInputTap(true)); err != nil {
+		t.Error(err)
+	}
+
+	if got := f.Commands(); len(got) != 1 {
+		t.Errorf("sent %q", got)
+	}
+}
+
+func TestConfigureInputXKBLayout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		layout = "English (US)"
+	)
+
+	keyboard := func() string {
+		return `{"identifier": "1:1:AT_Translated_Set_2_keyboard", "type": "keyboard",
+			"xkb_layout_names": ["` + layout + `"], "xkb_active_layout_index": 0}`
+	}
+
+	f := newFakeSway(t, func(f *fakeSway, typ uint32, payload string) string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch typ {
+		case ipcSubscribe:
+			return `{"success": true}`
+		case ipcRunCommand:
+			layout = "French"
+			f.Event(ipcEventInput, `{"change": "xkb_keymap", "input": `+keyboard()+`}`)
+			return `[{"success": true}]`
+		case ipcGetInputs:
+			return "[" + keyboard() + "]"
+		}
+		return "[]"
+	})
+
+	c := f.client(ctx, t)
+	id := sway.InputTypeIdentifier(sway.InputKeyboard)
+
+	// the number of layouts does not change, but the layout does, so it has
+	// to be sent
+	devices, err := sway.ConfigureInput(ctx, c, id, sway.WithInputXKBLayout("fr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(devices) != 1 || len(devices[0].XKBLayoutNames) != 1 || devices[0].XKBLayoutNames[0] != "French" {
+		t.Errorf("unexpected devices %+v", devices)
+	}
+
+	// layouts are sent along with settings that already hold
+	if _, err = sway.ConfigureInput(ctx, c, id, sway.WithInputXKBSwitchLayout(0), sway.WithInputXKBLayout("fr")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"input type:keyboard xkb_layout fr",
+		"input type:keyboard xkb_switch_layout 0; input type:keyboard xkb_layout fr",
+	}
+
+	got := f.Commands()
+	if len(got) != len(want) {
+		t.Fatalf("sent %q, want %q", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("command %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestConfigureInputInvalid(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newInputSway(t)
+	c := f.client(ctx, t)
+
+	keyboard := "1:1:AT_Translated_Set_2_keyboard"
+
+	for _, opt := range []sway.InputOption{
+		sway.WithInputTap(true),
+		sway.WithInputXKBSwitchLayout(2),
+		sway.WithInputAccelSpeed(2),
+		sway.WithInputAccelProfile(sway.AccelProfileNone),
+	} {
+		if _, err := sway.ConfigureInput(ctx, c, keyboard, opt); err == nil {
+			t.Error("expected an error")
+		}
+	}
+
+	if _, err := sway.ConfigureInput(ctx, c, "0:0:missing", sway.WithInputDWT(true)); err == nil {
+		t.Error("expected an error for a device that does not exist")
+	}
+
+	if got := f.Commands(); len(got) != 0 {
+		t.Errorf("sent %q, want no commands", got)
+	}
+}
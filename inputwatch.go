@@ -0,0 +1,299 @@
+package sway
+
+import (
+	"context"
+	"sync"
+)
+
+// the changes of the input events for devices that are added or removed
+const (
+	inputChangeAdded   = "added"
+	inputChangeRemoved = "removed"
+)
+
+// InputFunc is called by an InputWatcher when a device is added or removed
+type InputFunc func(ctx context.Context, i Input)
+
+// An InputRule configures input devices while a device that matches it is
+// present, e.g. to disable the touchpad while an external mouse is connected:
+//
+//	sway.InputRule{
+//		Match:      sway.MatchInputVendorProduct(0x046d, 0xc52b),
+//		Identifier: sway.InputTypeIdentifier(sway.InputTouchpad),
+//		Present:    []sway.InputOption{sway.WithInputSendEvents(sway.SendEventsDisabled)},
+//		Absent:     []sway.InputOption{sway.WithInputSendEvents(sway.SendEventsEnabled)},
+//	}
+type InputRule struct {
+	// Match returns true for the devices the rule applies to
+	Match func(i *Input) bool
+
+	// Identifier is passed to ConfigureInput. If it is empty, each matching
+	// device is configured itself when it is added and Absent is not used.
+	Identifier string
+
+	// Present is applied when the first matching device is added
+	Present []InputOption
+
+	// Absent is applied when the last matching device is removed, and when
+	// the watcher starts if no matching device is connected
+	Absent []InputOption
+}
+
+// MatchInputVendorProduct returns a Match func for InputRule that matches the
+// devices with the given vendor and product codes
+func MatchInputVendorProduct(vendor, product int64) func(i *Input) bool {
+	return func(i *Input) bool {
+		return i.Vendor == vendor && i.Product == product
+	}
+}
+
+// MatchInputType returns a Match func for InputRule that matches the devices
+// of type t
+func MatchInputType(t InputType) func(i *Input) bool {
+	return func(i *Input) bool {
+		return i.Type == t
+	}
+}
+
+// InputWatcherOption can be passed to NewInputWatcher and WatchInputs
+type InputWatcherOption func(*InputWatcher)
+
+// WithInputAddedFunc sets a func that is called when a device is added
+func WithInputAddedFunc(fn InputFunc) InputWatcherOption {
+	return func(w *InputWatcher) {
+		w.added = fn
+	}
+}
+
+// WithInputRemovedFunc sets a func that is called when a device is removed
+func WithInputRemovedFunc(fn InputFunc) InputWatcherOption {
+	return func(w *InputWatcher) {
+		w.removed = fn
+	}
+}
+
+// WithInputErrorFunc sets a func that is called when a rule can't be applied
+// or the devices can't be read
+func WithInputErrorFunc(fn func(ctx context.Context, err error)) InputWatcherOption {
+	return func(w *InputWatcher) {
+		w.errFn = fn
+	}
+}
+
+// WithInputRules sets the rules that are applied as devices are added and
+// removed
+func WithInputRules(rules ...InputRule) InputWatcherOption {
+	return func(w *InputWatcher) {
+		w.rules = append(w.rules, rules...)
+	}
+}
+
+// InputWatcher is an EventHandler that keeps track of the connected input
+// devices and applies InputRules as they are added and removed.
+//
+//	w, err := sway.NewInputWatcher(ctx, client, sway.WithInputRules(rule))
+//	if err != nil {
+//		return err
+//	}
+//
+//	return sway.Subscribe(ctx, w, sway.EventTypeInput)
+type InputWatcher struct {
+	EventHandler
+
+	client  Client
+	rules   []InputRule
+	added   InputFunc
+	removed InputFunc
+	errFn   func(context.Context, error)
+
+	// handleMu serializes the handling of events and the state of the rules
+	handleMu sync.Mutex
+	present  []bool
+
+	// identical devices share an identifier, so they are counted
+	mu      sync.Mutex
+	devices map[string]Input
+	counts  map[string]int
+}
+
+// NewInputWatcher gets the connected devices from c and applies the rules that
+// match them
+func NewInputWatcher(ctx context.Context, c Client, opts ...InputWatcherOption) (*InputWatcher, error) {
+	w := &InputWatcher{
+		EventHandler: NoOpEventHandler(),
+		client:       c,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	w.present = make([]bool, len(w.rules))
+
+	inputs, err := c.GetInputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w.setDevices(inputs, "")
+
+	w.handleMu.Lock()
+	defer w.handleMu.Unlock()
+
+	for i := range inputs {
+		w.applyDeviceRules(ctx, &inputs[i])
+	}
+	w.applyRules(ctx, true)
+
+	return w, nil
+}
+
+// WatchInputs applies the rules as devices are added and removed until ctx is
+// canceled or the connection to sway fails. It subscribes to input events
+// before reading the connected devices, so no device is missed in between.
+func WatchInputs(ctx context.Context, c Client, opts ...InputWatcherOption) error {
+	sub, err := newSubscription(ctx, c, EventTypeInput)
+	if err != nil {
+		return err
+	}
+
+	w, err := NewInputWatcher(ctx, c, opts...)
+	if err != nil {
+		_ = sub.conn.Close()
+		return err
+	}
+
+	return sub.handleEvents(ctx, w)
+}
+
+// Devices returns the connected devices by their identifiers. Identical
+// devices share an identifier and are only listed once.
+func (w *InputWatcher) Devices() map[string]Input {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ret := make(map[string]Input, len(w.devices))
+	for id, i := range w.devices {
+		ret[id] = i
+	}
+
+	return ret
+}
+
+// setDevices replaces the known devices with inputs. It returns how many
+// devices had the identifier id before and after.
+func (w *InputWatcher) setDevices(inputs []Input, id string) (before, after int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	before = w.counts[id]
+
+	w.devices = make(map[string]Input, len(inputs))
+	w.counts = make(map[string]int, len(inputs))
+
+	for _, i := range inputs {
+		w.devices[i.Identifier] = i
+		w.counts[i.Identifier]++
+	}
+
+	return before, w.counts[id]
+}
+
+// Input implements EventHandler. It updates the devices and applies the rules
+// when a device is added or removed. The devices are read from sway again for
+// each of those events, since identical devices share an identifier and an
+// event may already be reflected in the devices that were read before it.
+// Events that don't change the number of devices are not reported.
+func (w *InputWatcher) Input(ctx context.Context, e InputEvent) {
+	w.handleMu.Lock()
+	defer w.handleMu.Unlock()
+
+	id := e.Input.Identifier
+
+	if e.Change != inputChangeAdded && e.Change != inputChangeRemoved {
+		w.mu.Lock()
+		if _, ok := w.devices[id]; ok {
+			w.devices[id] = e.Input
+		}
+		w.mu.Unlock()
+		return
+	}
+
+	inputs, err := w.client.GetInputs(ctx)
+	if err != nil {
+		if w.errFn != nil {
+			w.errFn(ctx, err)
+		}
+		return
+	}
+
+	before, after := w.setDevices(inputs, id)
+
+	switch {
+	case after > before:
+		if w.added != nil {
+			w.added(ctx, e.Input)
+		}
+
+		w.applyDeviceRules(ctx, &e.Input)
+		w.applyRules(ctx, false)
+	case after < before:
+		if w.removed != nil {
+			w.removed(ctx, e.Input)
+		}
+
+		w.applyRules(ctx, false)
+	}
+}
+
+// applyDeviceRules applies the rules without an Identifier that match i to i
+func (w *InputWatcher) applyDeviceRules(ctx context.Context, i *Input) {
+	for _, r := range w.rules {
+		if r.Identifier == "" && r.Match(i) {
+			w.configure(ctx, i.Identifier, r.Present)
+		}
+	}
+}
+
+// applyRules applies Present or Absent for the rules with an Identifier when
+// the first matching device appears or the last one goes away. With force, they
+// are applied even if that did not change.
+func (w *InputWatcher) applyRules(ctx context.Context, force bool) {
+	devices := w.Devices()
+
+	for n, r := range w.rules {
+		if r.Identifier == "" {
+			continue
+		}
+
+		var present bool
+		for _, i := range devices {
+			if r.Match(&i) {
+				present = true
+				break
+			}
+		}
+
+		if !force && present == w.present[n] {
+			continue
+		}
+
+		w.present[n] = present
+
+		if present {
+			w.configure(ctx, r.Identifier, r.Present)
+		} else {
+			w.configure(ctx, r.Identifier, r.Absent)
+		}
+	}
+}
+
+func (w *InputWatcher) configure(ctx context.Context, identifier string, opts []InputOption) {
+	if len(opts) == 0 {
+		return
+	}
+
+	if _, err := ConfigureInput(ctx, w.client, identifier, opts...); err != nil && w.errFn != nil {
+		w.errFn(ctx, err)
+	}
+}
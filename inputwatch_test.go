@@ -0,0 +1,184 @@
+package sway_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+const mouseInput = `{"identifier": "1133:49291:Logitech_G502", "type": "pointer",
+	"vendor": 1133, "product": 49291, "libinput": {"send_events": "enabled"}}`
+
+// newHotplugSway returns a fake sway with a keyboard and a touchpad, and a func
+// that connects or disconnects one of any number of identical mice
+func newHotplugSway(t *testing.T) (*fakeSway, func(change string)) {
+	var (
+		mu   sync.Mutex
+		mice int
+
+		// left disabled while the mouse was connected before
+		events = "disabled"
+	)
+
+	touchpad := func() string {
+		return `{"identifier": "1739:52619:SYNA8004:00_06CB:CD8B_Touchpad", "type": "touchpad",
+			"libinput": {"send_events": "` + events + `"}}`
+	}
+
+	f := newFakeSway(t, func(f *fakeSway, typ uint32, payload string) string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch typ {
+		case ipcSubscribe:
+			return `{"success": true}`
+		case ipcRunCommand:
+			events = payload[strings.LastIndexByte(payload, ' ')+1:]
+			f.Event(ipcEventInput, `{"change": "libinput_config", "input": `+touchpad()+`}`)
+			return `[{"success": true}]`
+		case ipcGetInputs:
+			inputs := []string{keyboardInput, touchpad()}
+			for i := 0; i < mice; i++ {
+				inputs = append(inputs, mouseInput)
+			}
+			return "[" + strings.Join(inputs, ",") + "]"
+		}
+		return "[]"
+	})
+
+	plug := func(change string) {
+		mu.Lock()
+		if change == "added" {
+			mice++
+		} else {
+			mice--
+		}
+		mu.Unlock()
+
+		f.Event(ipcEventInput, `{"change": "`+change+`", "input": `+mouseInput+`}`)
+	}
+
+	return f, plug
+}
+
+// waitCommands waits until f received n commands and returns them
+func waitCommands(t *testing.T, f *fakeSway, n int) []string {
+	t.Helper()
+
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		got := f.Commands()
+		if len(got) >= n {
+			return got
+		}
+
+		if time.Since(start) > time.Second {
+			t.Fatalf("got commands %q, want %d", got, n)
+		}
+	}
+}
+
+func TestInputWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f, plug := newHotplugSway(t)
+	c := f.client(ctx, t)
+
+	var (
+		added   = make(chan string, 4)
+		removed = make(chan string, 4)
+		done    = make(chan struct{})
+	)
+
+	// the rule may still be applied when the test ends
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	go func() {
+		defer close(done)
+
+		_ = sway.WatchInputs(ctx, c,
+			sway.WithInputAddedFunc(func(_ context.Context, i sway.Input) {
+				added <- i.Identifier
+			}),
+			sway.WithInputRemovedFunc(func(_ context.Context, i sway.Input) {
+				removed <- i.Identifier
+			}),
+			sway.WithInputErrorFunc(func(ctx context.Context, err error) {
+				if ctx.Err() == nil {
+					t.Error(err)
+				}
+			}),
+			sway.WithInputRules(sway.InputRule{
+				Match:      sway.MatchInputVendorProduct(1133, 49291),
+				Identifier: sway.InputTypeIdentifier(sway.InputTouchpad),
+				Present:    []sway.InputOption{sway.WithInputSendEvents(sway.SendEventsDisabled)},
+				Absent:     []sway.InputOption{sway.WithInputSendEvents(sway.SendEventsEnabled)},
+			}),
+		)
+	}()
+
+	wait := func(ch chan string, what string) {
+		t.Helper()
+
+		select {
+		case id := <-ch:
+			if id != "1133:49291:Logitech_G502" {
+				t.Errorf("%s %q", what, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("the mouse was not %s", what)
+		}
+	}
+
+	// the mouse is not connected, so Absent is applied at startup. WatchInputs
+	// subscribed before that, so the events below are not missed.
+	waitCommands(t, f, 1)
+
+	plug("added")
+	wait(added, "added")
+	waitCommands(t, f, 2)
+
+	// an identical mouse shares the identifier, but is still reported
+	plug("added")
+	wait(added, "added")
+
+	// an event that doesn't change the devices, like one that was queued
+	// before they were read, is not reported
+	f.Event(ipcEventInput, `{"change": "added", "input": `+mouseInput+`}`)
+
+	// the rule stays applied while the other mouse is connected
+	plug("removed")
+	wait(removed, "removed")
+
+	plug("removed")
+	wait(removed, "removed")
+
+	got := waitCommands(t, f, 3)
+
+	if n := len(added) + len(removed); n != 0 {
+		t.Errorf("got %d more events", n)
+	}
+
+	want := []string{
+		"input type:touchpad events enabled",
+		"input type:touchpad events disabled",
+		"input type:touchpad events enabled",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("sent %q, want %q", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("command %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
@@ -42,6 +42,7 @@ const (
 
 const (
 	eventTypeWorkspace       messageType = 0x80000000
+	eventTypeOutput          messageType = 0x80000001
 	eventTypeMode            messageType = 0x80000002
 	eventTypeWindow          messageType = 0x80000003
 	eventTypeBarConfigUpdate messageType = 0x80000004
@@ -0,0 +1,201 @@
+package sway
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Modifiers is a set of modifier keys, as used in bindings and in
+// Binding.EventStateMask
+type Modifiers uint8
+
+const (
+	ModShift Modifiers = 1 << iota
+	ModLock
+	ModControl
+	ModMod1
+	ModMod2
+	ModMod3
+	ModMod4
+	ModMod5
+
+	// ModAlt is the usual modifier for the Alt key
+	ModAlt = ModMod1
+
+	// ModSuper is the usual modifier for the Super, or Logo, key
+	ModSuper = ModMod4
+)
+
+// modifierNames are the names sway uses in IPC replies, in the order of the
+// Modifiers bits
+var modifierNames = []string{"Shift", "Lock", "Control", "Mod1", "Mod2", "Mod3", "Mod4", "Mod5"}
+
+// modifierAliases are the other names sway accepts in the config
+var modifierAliases = map[string]Modifiers{
+	"ctrl":  ModControl,
+	"alt":   ModAlt,
+	"super": ModSuper,
+}
+
+// ParseModifier returns the modifier with the given name. The name is not case
+// sensitive and can be one of sway's aliases, such as "Ctrl", "Alt" or
+// "Super".
+func ParseModifier(name string) (Modifiers, bool) {
+	lower := strings.ToLower(name)
+
+	for i, n := range modifierNames {
+		if strings.ToLower(n) == lower {
+			return 1 << uint(i), true
+		}
+	}
+
+	m, ok := modifierAliases[lower]
+	return m, ok
+}
+
+// ParseModifiers returns the modifiers with the given names, e.g. the
+// EventStateMask of a Binding
+func ParseModifiers(names []string) (Modifiers, error) {
+	var ret Modifiers
+	for _, name := range names {
+		m, ok := ParseModifier(name)
+		if !ok {
+			return 0, fmt.Errorf("unknown modifier %q", name)
+		}
+		ret |= m
+	}
+	return ret, nil
+}
+
+// Has returns true if all of the modifiers in o are set in m
+func (m Modifiers) Has(o Modifiers) bool {
+	return m&o == o
+}
+
+// Names returns the names sway uses for the modifiers in m
+func (m Modifiers) Names() []string {
+	var ret []string
+	for i, n := range modifierNames {
+		if m&(1<<uint(i)) != 0 {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// String returns the modifiers joined with '+', as used in the config
+func (m Modifiers) String() string {
+	return strings.Join(m.Names(), "+")
+}
+
+// Keysym is the name of an XKB keysym, such as "Return" or "q"
+type Keysym string
+
+// keysyms maps the lowercase names of common keysyms, and the characters of
+// the punctuation keysyms, to the names XKB uses
+var keysyms = map[string]Keysym{}
+
+func init() {
+	for _, name := range []Keysym{
+		"Return", "Escape", "BackSpace", "Tab", "space", "Delete", "Insert",
+		"Home", "End", "Prior", "Next", "Left", "Right", "Up", "Down",
+		"Print", "Menu", "Pause", "Scroll_Lock", "Num_Lock", "Caps_Lock",
+		"Shift_L", "Shift_R", "Control_L", "Control_R", "Alt_L", "Alt_R",
+		"Super_L", "Super_R",
+		"XF86AudioMute", "XF86AudioLowerVolume", "XF86AudioRaiseVolume",
+		"XF86AudioMicMute", "XF86AudioPlay", "XF86AudioPause", "XF86AudioStop",
+		"XF86AudioNext", "XF86AudioPrev", "XF86MonBrightnessUp",
+		"XF86MonBrightnessDown", "XF86PowerOff", "XF86Calculator",
+		"XF86Search", "XF86Mail",
+	} {
+		keysyms[strings.ToLower(string(name))] = name
+	}
+
+	for i := 1; i <= 24; i++ {
+		name := Keysym(fmt.Sprintf("F%d", i))
+		keysyms[strings.ToLower(string(name))] = name
+	}
+
+	for ch, name := range map[string]Keysym{
+		"-": "minus", "=": "equal", "[": "bracketleft", "]": "bracketright",
+		";": "semicolon", "'": "apostrophe", "`": "grave", `\`: "backslash",
+		",": "comma", ".": "period", "/": "slash", "!": "exclam", "@": "at",
+		"#": "numbersign", "$": "dollar", "%": "percent", "^": "asciicircum",
+		"&": "ampersand", "*": "asterisk", "(": "parenleft", ")": "parenright",
+		"_": "underscore", "+": "plus", "{": "braceleft", "}": "braceright",
+		":": "colon", `"`: "quotedbl", "~": "asciitilde", "|": "bar",
+		"<": "less", ">": "greater", "?": "question",
+	} {
+		keysyms[ch] = name
+		keysyms[string(name)] = name
+	}
+
+	// aliases that XKB resolves to the same keysym
+	keysyms["page_up"] = "Prior"
+	keysyms["page_down"] = "Next"
+}
+
+// ParseKeysym returns the keysym with the given name. Like sway, the name is
+// not case sensitive, so letters are returned in lower case and names such as
+// "return" are returned as "Return". Aliases such as "Page_Up" and punctuation
+// characters such as "," are returned as the XKB name, "Prior" and "comma".
+// Unknown names are returned unchanged.
+func ParseKeysym(name string) Keysym {
+	if k, ok := keysyms[strings.ToLower(name)]; ok {
+		return k
+	}
+
+	if len(name) == 1 && name[0] >= 'A' && name[0] <= 'Z' {
+		return Keysym(strings.ToLower(name))
+	}
+
+	return Keysym(name)
+}
+
+// String implements fmt.Stringer
+func (k Keysym) String() string {
+	return string(k)
+}
+
+// Modifiers returns the modifiers of the binding. Unknown modifier names are
+// ignored.
+func (b Binding) Modifiers() Modifiers {
+	var ret Modifiers
+	for _, name := range b.EventStateMask {
+		if m, ok := ParseModifier(name); ok {
+			ret |= m
+		}
+	}
+	return ret
+}
+
+// Keysym returns the normalized keysym of a bindsym binding, or an empty
+// string for other bindings
+func (b Binding) Keysym() Keysym {
+	if b.Symbol == nil {
+		return ""
+	}
+	return ParseKeysym(*b.Symbol)
+}
+
+// Config returns the binding as a config statement, e.g.
+// "bindsym Mod4+Shift+q kill". Keyboard bindings without a keysym are
+// returned as bindcode and mouse bindings use the button number.
+func (b Binding) Config() string {
+	var key, kind string
+
+	switch {
+	case b.Symbol != nil:
+		kind, key = "bindsym", string(b.Keysym())
+	case b.InputType == BindingMouse:
+		kind, key = "bindsym", fmt.Sprintf("button%d", b.InputCode)
+	default:
+		kind, key = "bindcode", fmt.Sprintf("%d", b.InputCode)
+	}
+
+	if mods := b.Modifiers().String(); mods != "" {
+		key = mods + "+" + key
+	}
+
+	return kind + " " + key + " " + b.Command
+}
@@ -0,0 +1,93 @@
+package sway_test
+
+import (
+	"reflect"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestParseModifiers(t *testing.T) {
+	m, err := sway.ParseModifiers([]string{"Super", "shift", "Ctrl", "Mod1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := sway.ModSuper | sway.ModShift | sway.ModControl | sway.ModAlt; m != want {
+		t.Errorf("got %v, want %v", m, want)
+	}
+
+	if got, want := m.Names(), []string{"Shift", "Control", "Mod1", "Mod4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names: got %q, want %q", got, want)
+	}
+
+	if got, want := m.String(), "Shift+Control+Mod1+Mod4"; got != want {
+		t.Errorf("String: got %q, want %q", got, want)
+	}
+
+	if !m.Has(sway.ModMod4|sway.ModShift) || m.Has(sway.ModMod5) {
+		t.Error("Has returned the wrong result")
+	}
+
+	// sway calls caps lock "Lock"
+	for _, name := range []string{"Hyper", "Caps"} {
+		if _, err := sway.ParseModifiers([]string{name}); err == nil {
+			t.Errorf("expected an error for %q", name)
+		}
+	}
+}
+
+func TestParseKeysym(t *testing.T) {
+	for in, want := range map[string]sway.Keysym{
+		"Q":             "q",
+		"q":             "q",
+		"return":        "Return",
+		"RETURN":        "Return",
+		"Page_Up":       "Prior",
+		",":             "comma",
+		"Comma":         "comma",
+		"f12":           "F12",
+		"xf86audiomute": "XF86AudioMute",
+		"ssharp":        "ssharp",
+	} {
+		if got := sway.ParseKeysym(in); got != want {
+			t.Errorf("%q: got %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestBindingConfig(t *testing.T) {
+	sym := "Q"
+
+	for _, tc := range []struct {
+		binding sway.Binding
+		want    string
+	}{{
+		sway.Binding{
+			Command:        "kill",
+			EventStateMask: []string{"Mod4", "Shift"},
+			Symbol:         &sym,
+			InputType:      sway.BindingKeyboard,
+		},
+		"bindsym Shift+Mod4+q kill",
+	}, {
+		sway.Binding{
+			Command:   "exec foot",
+			InputCode: 36,
+			InputType: sway.BindingKeyboard,
+		},
+		"bindcode 36 exec foot",
+	}, {
+		sway.Binding{
+			Command:        "floating toggle",
+			EventStateMask: []string{"Mod4"},
+			InputCode:      2,
+			InputType:      sway.BindingMouse,
+		},
+		"bindsym Mod4+button2 floating toggle",
+	}} {
+		if got := tc.binding.Config(); got != tc.want {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package sway
+
+import "math"
+
+// Direction is a direction on screen as used by commands such as "focus left"
+type Direction string
+
+const (
+	DirectionLeft  Direction = "left"
+	DirectionRight Direction = "right"
+	DirectionUp    Direction = "up"
+	DirectionDown  Direction = "down"
+)
+
+// String implements fmt.Stringer
+func (d Direction) String() string {
+	return string(d)
+}
+
+// IsKnown returns true if d is one of the Direction values listed above
+func (d Direction) IsKnown() bool {
+	switch d {
+	case DirectionLeft,
+		DirectionRight,
+		DirectionUp,
+		DirectionDown:
+		return true
+	}
+	return false
+}
+
+// isBeyond returns true if r lies entirely in direction d of ref
+func (d Direction) isBeyond(ref, r Rect) bool {
+	switch d {
+	case DirectionLeft:
+		return r.X+r.Width <= ref.X
+	case DirectionRight:
+		return r.X >= ref.X+ref.Width
+	case DirectionUp:
+		return r.Y+r.Height <= ref.Y
+	case DirectionDown:
+		return r.Y >= ref.Y+ref.Height
+	}
+	return false
+}
+
+// closest returns the index of the rect that is in direction d of ref and
+// nearest to its center, or -1 if there is none. This is the same approach
+// wlroots uses to find adjacent outputs.
+func (d Direction) closest(ref Rect, rects []Rect) int {
+	center := ref.Center()
+	ret, best := -1, math.Inf(1)
+
+	for i, r := range rects {
+		if r.Empty() || !d.isBeyond(ref, r) {
+			continue
+		}
+
+		if dist := r.Distance(center); dist < best {
+			ret, best = i, dist
+		}
+	}
+
+	return ret
+}
+
+// Neighbor returns the visible window in tree that is visually in direction d
+// from the from node, or nil if there is none. Since it only considers
+// geometry, it works across outputs of any scale and position and for
+// floating windows. It does not consider the focus history that sway uses to
+// pick between several windows in a tabbed or stacked container.
+func Neighbor(tree, from *Node, d Direction) *Node {
+	if from == nil {
+		return nil
+	}
+
+	var (
+		views []*Node
+		rects []Rect
+	)
+
+	tree.TraverseNodes(func(n *Node) bool {
+		if n.ID != from.ID && n.isVisibleView() {
+			views = append(views, n)
+			rects = append(rects, n.Rect)
+		}
+		return false
+	})
+
+	if i := d.closest(from.Rect, rects); i >= 0 {
+		return views[i]
+	}
+
+	return nil
+}
+
+func (n *Node) isVisibleView() bool {
+	if n.Type != NodeCon && n.Type != NodeFloatingCon {
+		return false
+	}
+
+	if len(n.Nodes) > 0 || len(n.FloatingNodes) > 0 {
+		return false
+	}
+
+	return n.Visible == nil || *n.Visible
+}
+
+// OutputNeighbor returns the active output that is in direction d from the
+// from output, or nil if there is none
+func OutputNeighbor(outputs []Output, from *Output, d Direction) *Output {
+	if from == nil {
+		return nil
+	}
+
+	rects := make([]Rect, len(outputs))
+	for i, o := range outputs {
+		if o.Active && o.Name != from.Name {
+			rects[i] = o.Rect
+		}
+	}
+
+	if i := d.closest(from.Rect, rects); i >= 0 {
+		return &outputs[i]
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package sway_test
+
+import (
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestNeighbor(t *testing.T) {
+	visible, hidden := true, false
+
+	view := func(id int64, r sway.Rect, v *bool) *sway.Node {
+		return &sway.Node{ID: id, Type: sway.NodeCon, Rect: r, Visible: v}
+	}
+
+	a := view(10, sway.Rect{X: 0, Y: 0, Width: 960, Height: 1080}, &visible)
+	b := view(11, sway.Rect{X: 960, Y: 0, Width: 960, Height: 540}, &visible)
+	c := view(12, sway.Rect{X: 960, Y: 540, Width: 960, Height: 540}, &visible)
+	d := view(13, sway.Rect{X: 1920, Y: 0, Width: 1440, Height: 2560}, &visible)
+	e := view(14, sway.Rect{X: 0, Y: 0, Width: 1920, Height: 1080}, &hidden)
+
+	tree := &sway.Node{
+		ID:   1,
+		Type: sway.NodeRoot,
+		Nodes: []*sway.Node{
+			{ID: 2, Type: sway.NodeOutput, Nodes: []*sway.Node{
+				{ID: 4, Type: sway.NodeWorkspace, Nodes: []*sway.Node{
+					a,
+					{ID: 5, Type: sway.NodeCon, Layout: sway.LayoutSplitV, Nodes: []*sway.Node{b, c}},
+				}},
+				{ID: 6, Type: sway.NodeWorkspace, Nodes: []*sway.Node{e}},
+			}},
+			{ID: 3, Type: sway.NodeOutput, Nodes: []*sway.Node{
+				{ID: 7, Type: sway.NodeWorkspace, Nodes: []*sway.Node{d}},
+			}},
+		},
+	}
+
+	for _, tc := range []struct {
+		from *sway.Node
+		dir  sway.Direction
+		want *sway.Node
+	}{
+		{a, sway.DirectionLeft, nil},
+		{a, sway.DirectionRight, b},
+		{b, sway.DirectionDown, c},
+		{c, sway.DirectionUp, b},
+		{b, sway.DirectionRight, d},
+		{d, sway.DirectionLeft, c},
+		{c, sway.DirectionLeft, a},
+		{d, sway.DirectionUp, nil},
+	} {
+		if got := sway.Neighbor(tree, tc.from, tc.dir); got != tc.want {
+			t.Errorf("Neighbor(%d, %s): got %+v, want %+v", tc.from.ID, tc.dir, got, tc.want)
+		}
+	}
+}
+
+func TestOutputNeighbor(t *testing.T) {
+	outputs := loadOutputs(t)
+	edp, dp := &outputs[0], &outputs[1]
+
+	if got := sway.OutputNeighbor(outputs, edp, sway.DirectionRight); got != dp {
+		t.Errorf("right of eDP-1: got %+v", got)
+	}
+
+	if got := sway.OutputNeighbor(outputs, dp, sway.DirectionLeft); got != edp {
+		t.Errorf("left of DP-1: got %+v", got)
+	}
+
+	for _, dir := range []sway.Direction{sway.DirectionUp, sway.DirectionDown, sway.DirectionLeft} {
+		if got := sway.OutputNeighbor(outputs, edp, dir); got != nil {
+			t.Errorf("%s of eDP-1: got %s", dir, got.Name)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package sway
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RefreshFromHz converts a refresh rate in Hz to a Refresh
+func RefreshFromHz(hz float64) Refresh {
+	return Refresh(math.Round(hz * 1000))
+}
+
+// Hz returns the refresh rate in Hz
+func (r Refresh) Hz() float64 {
+	return float64(r) / 1000
+}
+
+// String returns the refresh rate in Hz as used in sway output commands, e.g.
+// "59.951Hz"
+func (r Refresh) String() string {
+	return strconv.FormatFloat(r.Hz(), 'f', -1, 64) + "Hz"
+}
+
+// String returns the mode as used in sway output commands, e.g.
+// "2560x1440@59.951Hz". The refresh rate is omitted when it is 0.
+func (m OutputMode) String() string {
+	s := fmt.Sprintf("%dx%d", m.Width, m.Height)
+	if m.Refresh != 0 {
+		s += "@" + m.Refresh.String()
+	}
+	return s
+}
+
+// ParseOutputMode parses a mode in the WIDTHxHEIGHT[@RATE[Hz]] form used by the
+// sway output mode command, e.g. "2560x1440@144Hz"
+func ParseOutputMode(s string) (OutputMode, error) {
+	var m OutputMode
+
+	res, rate, hasRate := s, "", false
+	if i := strings.IndexByte(s, '@'); i >= 0 {
+		res, rate, hasRate = s[:i], strings.TrimSuffix(s[i+1:], "Hz"), true
+	}
+
+	i := strings.IndexByte(res, 'x')
+	if i < 0 {
+		return m, fmt.Errorf("invalid mode %q", s)
+	}
+
+	var err error
+	if m.Width, err = strconv.ParseInt(res[:i], 10, 64); err != nil || m.Width <= 0 {
+		return m, fmt.Errorf("invalid mode %q: bad width", s)
+	}
+
+	if m.Height, err = strconv.ParseInt(res[i+1:], 10, 64); err != nil || m.Height <= 0 {
+		return m, fmt.Errorf("invalid mode %q: bad height", s)
+	}
+
+	if hasRate {
+		hz, err := strconv.ParseFloat(rate, 64)
+		if err != nil || hz <= 0 {
+			return m, fmt.Errorf("invalid mode %q: bad refresh rate", s)
+		}
+		m.Refresh = RefreshFromHz(hz)
+	}
+
+	return m, nil
+}
+
+// HighestMode returns the mode with the highest resolution, preferring the
+// highest refresh rate among modes of the same resolution. sway does not
+// report which mode the output prefers. It returns nil if the output has no
+// modes.
+func (o Output) HighestMode() *OutputMode {
+	var ret *OutputMode
+	for i := range o.Modes {
+		m := &o.Modes[i]
+		if ret == nil {
+			ret = m
+			continue
+		}
+
+		area, best := m.Width*m.Height, ret.Width*ret.Height
+		if area > best || area == best && m.Refresh > ret.Refresh {
+			ret = m
+		}
+	}
+	return ret
+}
+
+// MatchMode returns the supported mode that matches s, which is in the form
+// accepted by ParseOutputMode. Without a refresh rate, the matching mode with
+// the highest refresh rate is returned. Otherwise the mode with the closest
+// refresh rate, within 1Hz, is returned.
+func (o Output) MatchMode(s string) (*OutputMode, error) {
+	want, err := ParseOutputMode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ret  *OutputMode
+		diff int64 = math.MaxInt64
+	)
+
+	for i := range o.Modes {
+		m := &o.Modes[i]
+		if m.Width != want.Width || m.Height != want.Height {
+			continue
+		}
+
+		if want.Refresh == 0 {
+			if ret == nil || m.Refresh > ret.Refresh {
+				ret = m
+			}
+			continue
+		}
+
+		d := int64(m.Refresh - want.Refresh)
+		if d < 0 {
+			d = -d
+		}
+
+		if d <= 1000 && d < diff {
+			ret, diff = m, d
+		}
+	}
+
+	if ret == nil {
+		return nil, fmt.Errorf("output %s does not support mode %s", o.Name, s)
+	}
+
+	return ret, nil
+}
+
+// IsRotated returns true if t rotates by 90 or 270 degrees, which swaps the
+// width and height of an output
+func (t Transform) IsRotated() bool {
+	switch t {
+	case Transform90, Transform270, TransformFlipped90, TransformFlipped270:
+		return true
+	}
+	return false
+}
+
+// LogicalSize returns the size of the output in the layout after applying its
+// transform and scale to the current mode, the same way sway does
+func (o Output) LogicalSize() (width, height int64) {
+	width, height = o.CurrentMode.Width, o.CurrentMode.Height
+	if o.Transform.IsRotated() {
+		width, height = height, width
+	}
+
+	if o.Scale > 0 {
+		width = int64(float64(width) / o.Scale)
+		height = int64(float64(height) / o.Scale)
+	}
+
+	return width, height
+}
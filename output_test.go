@@ -0,0 +1,90 @@
+package sway_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestRefresh(t *testing.T) {
+	var m sway.OutputMode
+	if err := json.Unmarshal([]byte(`{"width":2560,"height":1440,"refresh":59951}`), &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Refresh != 59951 || m.Refresh.Hz() != 59.951 {
+		t.Errorf("unexpected refresh: %d", m.Refresh)
+	}
+
+	if got := m.String(); got != "2560x1440@59.951Hz" {
+		t.Errorf("String: got %q", got)
+	}
+
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var again sway.OutputMode
+	if err = json.Unmarshal(out, &again); err != nil || again != m {
+		t.Errorf("round trip: got %+v (%v), want %+v", again, err, m)
+	}
+}
+
+func TestParseOutputMode(t *testing.T) {
+	for in, want := range map[string]sway.OutputMode{
+		"2560x1440":          {Width: 2560, Height: 1440},
+		"2560x1440@144Hz":    {Width: 2560, Height: 1440, Refresh: 144000},
+		"1920x1080@59.94":    {Width: 1920, Height: 1080, Refresh: 59940},
+		"3840x2160@59.997Hz": {Width: 3840, Height: 2160, Refresh: 59997},
+	} {
+		got, err := sway.ParseOutputMode(in)
+		if err != nil || got != want {
+			t.Errorf("%q: got %+v (%v), want %+v", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "2560", "x1440", "2560x", "2560x1440@", "2560x1440@fastHz", "-1x5"} {
+		if _, err := sway.ParseOutputMode(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestOutputModes(t *testing.T) {
+	outputs := loadOutputs(t)
+	edp, dp := outputs[0], outputs[1]
+
+	if got := edp.HighestMode(); got == nil || got.Refresh != 59997 {
+		t.Errorf("HighestMode: got %+v", got)
+	}
+
+	if got := dp.HighestMode(); got == nil || got.Width != 2560 {
+		t.Errorf("HighestMode: got %+v", got)
+	}
+
+	for in, want := range map[string]sway.Refresh{
+		"3840x2160":      59997,
+		"3840x2160@48Hz": 47998,
+		"3840x2160@60Hz": 59997,
+	} {
+		got, err := edp.MatchMode(in)
+		if err != nil || got.Refresh != want {
+			t.Errorf("MatchMode(%q): got %+v (%v), want refresh %d", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"3840x2160@30Hz", "1280x720"} {
+		if _, err := edp.MatchMode(in); err == nil {
+			t.Errorf("MatchMode(%q): expected error", in)
+		}
+	}
+
+	for _, o := range outputs {
+		w, h := o.LogicalSize()
+		if w != o.Rect.Width || h != o.Rect.Height {
+			t.Errorf("%s LogicalSize: got %dx%d, want %dx%d", o.Name, w, h, o.Rect.Width, o.Rect.Height)
+		}
+	}
+}
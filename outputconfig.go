@@ -0,0 +1,244 @@
+package sway
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/joshuarubin/go-sway/command"
+	"go.uber.org/multierr"
+)
+
+// OutputOption is a setting passed to ConfigureOutput
+type OutputOption func(*outputConfig)
+
+type outputConfig struct {
+	// output is the state of the output before it is configured
+	output *Output
+
+	args   []string
+	checks []func(o *Output) error
+	err    error
+
+	// enabled is set by WithOutputEnabled, it is checked separately since it
+	// is the only setting that can be confirmed on a disabled output
+	enabled *bool
+}
+
+func (c *outputConfig) add(check func(o *Output) error, args ...string) {
+	c.args = append(c.args, args...)
+	if check != nil {
+		c.checks = append(c.checks, check)
+	}
+}
+
+// WithOutputEnabled enables or disables the output
+func WithOutputEnabled(enabled bool) OutputOption {
+	return func(c *outputConfig) {
+		arg := "disable"
+		if enabled {
+			arg = "enable"
+		}
+
+		c.enabled = &enabled
+		c.add(nil, arg)
+	}
+}
+
+// WithOutputMode sets the mode of the output. The mode is in the form accepted
+// by ParseOutputMode and has to match one of the modes the output supports,
+// see Output.MatchMode.
+func WithOutputMode(mode string) OutputOption {
+	return func(c *outputConfig) {
+		m, err := c.output.MatchMode(mode)
+		if err != nil {
+			c.err = multierr.Append(c.err, err)
+			return
+		}
+
+		c.add(func(o *Output) error {
+			cur := o.CurrentMode
+			if cur.Width != m.Width || cur.Height != m.Height || cur.Refresh != m.Refresh {
+				return fmt.Errorf("mode is %s, want %s", cur, m)
+			}
+			return nil
+		}, "mode", m.String())
+	}
+}
+
+// WithOutputPosition sets the position of the output in the layout
+func WithOutputPosition(x, y int64) OutputOption {
+	return func(c *outputConfig) {
+		c.add(func(o *Output) error {
+			if o.Rect.X != x || o.Rect.Y != y {
+				return fmt.Errorf("position is %d,%d, want %d,%d", o.Rect.X, o.Rect.Y, x, y)
+			}
+			return nil
+		}, "position", strconv.FormatInt(x, 10), strconv.FormatInt(y, 10))
+	}
+}
+
+// WithOutputScale sets the scale of the output. sway may round fractional
+// scales to a multiple of 1/120.
+func WithOutputScale(scale float64) OutputOption {
+	return func(c *outputConfig) {
+		if scale <= 0 {
+			c.err = multierr.Append(c.err, fmt.Errorf("invalid scale %v", scale))
+			return
+		}
+
+		c.add(func(o *Output) error {
+			if math.Abs(o.Scale-scale) > 1.0/120 {
+				return fmt.Errorf("scale is %v, want %v", o.Scale, scale)
+			}
+			return nil
+		}, "scale", strconv.FormatFloat(scale, 'f', -1, 64))
+	}
+}
+
+// WithOutputTransform sets the transform of the output
+func WithOutputTransform(t Transform) OutputOption {
+	return func(c *outputConfig) {
+		if !t.IsKnown() {
+			c.err = multierr.Append(c.err, fmt.Errorf("invalid transform %q", t))
+			return
+		}
+
+		c.add(func(o *Output) error {
+			if o.Transform != t {
+				return fmt.Errorf("transform is %s, want %s", o.Transform, t)
+			}
+			return nil
+		}, "transform", string(t))
+	}
+}
+
+// WithOutputAdaptiveSync enables or disables adaptive sync, also known as
+// variable refresh rate
+func WithOutputAdaptiveSync(enabled bool) OutputOption {
+	return func(c *outputConfig) {
+		arg, status := "off", "disabled"
+		if enabled {
+			arg, status = "on", "enabled"
+		}
+
+		c.add(func(o *Output) error {
+			if o.AdaptiveSyncStatus != status {
+				return fmt.Errorf("adaptive sync is %s, want %s", o.AdaptiveSyncStatus, status)
+			}
+			return nil
+		}, "adaptive_sync", arg)
+	}
+}
+
+// WithOutputPower turns the output on or off without disabling it. It uses the
+// power command, which replaced dpms in sway 1.8.
+func WithOutputPower(on bool) OutputOption {
+	return func(c *outputConfig) {
+		arg := "off"
+		if on {
+			arg = "on"
+		}
+
+		c.add(func(o *Output) error {
+			// sway versions before 1.8 only report dpms
+			if power := o.Power || o.DPMS; power != on {
+				return fmt.Errorf("power is %v, want %v", power, on)
+			}
+			return nil
+		}, "power", arg)
+	}
+}
+
+// BackgroundMode is how a background image is fit to an output
+type BackgroundMode string
+
+const (
+	BackgroundStretch BackgroundMode = "stretch"
+	BackgroundFill    BackgroundMode = "fill"
+	BackgroundFit     BackgroundMode = "fit"
+	BackgroundCenter  BackgroundMode = "center"
+	BackgroundTile    BackgroundMode = "tile"
+)
+
+// WithOutputBackground sets the background image of the output. sway does not
+// report the background, so it can't be confirmed.
+func WithOutputBackground(file string, mode BackgroundMode) OutputOption {
+	return func(c *outputConfig) {
+		c.add(nil, "bg", file, string(mode))
+	}
+}
+
+// WithOutputBackgroundColor sets the background of the output to a solid
+// color. sway does not report the background, so it can't be confirmed.
+func WithOutputBackgroundColor(color Color) OutputOption {
+	return func(c *outputConfig) {
+		c.add(nil, "bg", fmt.Sprintf("#%02x%02x%02x", color.R, color.G, color.B), "solid_color")
+	}
+}
+
+// ConfigureOutput applies settings to the named output in a single output
+// command. The settings are validated against the output first, and the output
+// is read back afterwards to confirm that the settings took effect. Only
+// WithOutputEnabled is confirmed for outputs that end up disabled. The output
+// state after the command is returned, even if it does not match.
+func ConfigureOutput(ctx context.Context, c Client, name string, opts ...OutputOption) (*Output, error) {
+	o, err := getOutput(ctx, c, name)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := outputConfig{output: o}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.err != nil {
+		return nil, fmt.Errorf("output %s: %v", name, cfg.err)
+	}
+
+	if len(cfg.args) == 0 {
+		return o, nil
+	}
+
+	if err = runCommands(ctx, c, command.Output(name, cfg.args...)); err != nil {
+		return nil, err
+	}
+
+	if o, err = getOutput(ctx, c, name); err != nil {
+		return nil, err
+	}
+
+	var errs error
+	if cfg.enabled != nil && o.Active != *cfg.enabled {
+		errs = fmt.Errorf("active is %v, want %v", o.Active, *cfg.enabled)
+	}
+
+	if o.Active {
+		for _, check := range cfg.checks {
+			errs = multierr.Append(errs, check(o))
+		}
+	}
+
+	if errs != nil {
+		return o, fmt.Errorf("output %s was not configured: %v", name, errs)
+	}
+
+	return o, nil
+}
+
+func getOutput(ctx context.Context, c Client, name string) (*Output, error) {
+	outputs, err := c.GetOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range outputs {
+		if outputs[i].Name == name {
+			return &outputs[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("output %s not found", name)
+}
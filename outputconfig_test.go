@@ -0,0 +1,107 @@
+package sway_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+const (
+	outputBefore = `[{"name": "DP-1", "active": true, "scale": 1, "transform": "normal",
+		"adaptive_sync_status": "disabled", "power": true,
+		"rect": {"x": 0, "y": 0, "width": 1920, "height": 1080},
+		"modes": [
+			{"width": 1920, "height": 1080, "refresh": 60000},
+			{"width": 2560, "height": 1440, "refresh": 59951}
+		],
+		"current_mode": {"width": 1920, "height": 1080, "refresh": 60000}}]`
+
+	// adaptive sync is not supported by the output, so sway leaves it disabled
+	outputAfter = `[{"name": "DP-1", "active": true, "scale": 2, "transform": "90",
+		"adaptive_sync_status": "disabled", "power": true,
+		"rect": {"x": 1920, "y": 0, "width": 720, "height": 1280},
+		"modes": [
+			{"width": 1920, "height": 1080, "refresh": 60000},
+			{"width": 2560, "height": 1440, "refresh": 59951}
+		],
+		"current_mode": {"width": 2560, "height": 1440, "refresh": 59951}}]`
+)
+
+func newOutputSway(t *testing.T) *fakeSway {
+	return newFakeSway(t, func(f *fakeSway, typ uint32, payload string) string {
+		switch typ {
+		case ipcRunCommand:
+			return `[{"success":true}]`
+		case ipcGetOutputs:
+			if len(f.Commands()) > 0 {
+				return outputAfter
+			}
+			return outputBefore
+		}
+		return "[]"
+	})
+}
+
+func TestConfigureOutput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newOutputSway(t)
+	c := f.client(ctx, t)
+
+	o, err := sway.ConfigureOutput(ctx, c, "DP-1",
+		sway.WithOutputMode("2560x1440@60"),
+		sway.WithOutputPosition(1920, 0),
+		sway.WithOutputScale(2),
+		sway.WithOutputTransform(sway.Transform90),
+		sway.WithOutputBackground("/tmp/bg.png", sway.BackgroundFill),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if o.Scale != 2 {
+		t.Errorf("got scale %v, want 2", o.Scale)
+	}
+
+	want := "output DP-1 mode 2560x1440@59.951Hz position 1920 0 scale 2 transform 90 bg /tmp/bg.png fill"
+	if got := f.Commands(); len(got) != 1 || got[0] != want {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+
+	if _, err = sway.ConfigureOutput(ctx, c, "DP-1", sway.WithOutputBackgroundColor(sway.Color{R: 0x12, G: 0x34, B: 0x56})); err != nil {
+		t.Fatal(err)
+	}
+
+	want = `output DP-1 bg "#123456" solid_color`
+	if got := f.Commands(); len(got) != 2 || got[1] != want {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+
+	_, err = sway.ConfigureOutput(ctx, c, "DP-1", sway.WithOutputAdaptiveSync(true))
+	if err == nil || !strings.Contains(err.Error(), "adaptive sync is disabled, want enabled") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestConfigureOutputInvalid(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newOutputSway(t)
+	c := f.client(ctx, t)
+
+	if _, err := sway.ConfigureOutput(ctx, c, "DP-1", sway.WithOutputMode("800x600")); err == nil {
+		t.Error("expected an error for an unsupported mode")
+	}
+
+	if _, err := sway.ConfigureOutput(ctx, c, "HDMI-A-1", sway.WithOutputScale(1)); err == nil {
+		t.Error("expected an error for an output that does not exist")
+	}
+
+	if got := f.Commands(); len(got) != 0 {
+		t.Errorf("sent %q, want no commands", got)
+	}
+}
@@ -0,0 +1,122 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sway "github.com/joshuarubin/go-sway"
+	"github.com/joshuarubin/go-sway/command"
+	"go.uber.org/multierr"
+)
+
+// Apply gets the connected outputs from sway and applies the first profile
+// that matches them. It returns an error if no profile matches.
+func Apply(ctx context.Context, c sway.Client, profiles []*Profile) (*Match, error) {
+	outputs, err := c.GetOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := MatchOutputs(profiles, outputs)
+	if m == nil {
+		return nil, fmt.Errorf("no profile matches outputs %s", outputNames(outputs))
+	}
+
+	return m, m.Apply(ctx, c)
+}
+
+func outputNames(outputs []sway.Output) string {
+	names := make([]string, len(outputs))
+	for i, o := range outputs {
+		names[i] = o.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Apply configures the matched outputs and then assigns the workspaces of the
+// profile to their outputs. Outputs are enabled before others are disabled so
+// that there is always an enabled output. Errors don't stop the remaining
+// settings from being applied, they are all returned together.
+func (m *Match) Apply(ctx context.Context, c sway.Client) error {
+	var errs error
+
+	for _, disable := range []bool{false, true} {
+		for _, o := range m.outputs {
+			s := m.Outputs[o.Name]
+			if s == nil || (s.Enabled != nil && !*s.Enabled) != disable {
+				continue
+			}
+
+			opts := s.options()
+			if len(opts) == 0 {
+				continue
+			}
+
+			if _, err := sway.ConfigureOutput(ctx, c, o.Name, opts...); err != nil {
+				errs = multierr.Append(errs, err)
+			}
+		}
+	}
+
+	return multierr.Append(errs, m.assignWorkspaces(ctx, c))
+}
+
+// assignWorkspaces assigns each workspace of the profile to its output and
+// moves it there if it already exists elsewhere. The focused workspace is
+// focused again afterwards.
+func (m *Match) assignWorkspaces(ctx context.Context, c sway.Client) error {
+	if len(m.Profile.Workspaces) == 0 {
+		return nil
+	}
+
+	workspaces, err := c.GetWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		errs    error
+		focused string
+		moved   bool
+	)
+
+	for _, ws := range workspaces {
+		if ws.Focused {
+			focused = ws.Name
+		}
+	}
+
+	for _, a := range m.Profile.Workspaces {
+		output, ok := m.output(a.Output)
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("%s: output %q is not connected", a.Pos, a.Output))
+			continue
+		}
+
+		if _, err = sway.RunCommands(ctx, c, command.New("workspace", a.Name, "output", output).String()); err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		for _, ws := range workspaces {
+			if ws.Name != a.Name || ws.Output == output {
+				continue
+			}
+
+			if _, err = sway.SwitchToWorkspace(ctx, c, a.Name); err == nil {
+				_, err = sway.MoveWorkspaceToOutput(ctx, c, output)
+			}
+
+			errs = multierr.Append(errs, err)
+			moved = true
+		}
+	}
+
+	if moved && focused != "" {
+		_, err = sway.SwitchToWorkspace(ctx, c, focused)
+		errs = multierr.Append(errs, err)
+	}
+
+	return errs
+}
@@ -0,0 +1,134 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+// ApplyFunc is called by a Manager after it applies a profile. m is nil if no
+// profile matches or the outputs could not be read, err is set if applying the
+// profile failed.
+type ApplyFunc func(ctx context.Context, m *Match, err error)
+
+// Manager is a sway.OutputHandler that applies the matching profile whenever
+// the set of connected outputs changes. Changes to the settings of the outputs,
+// such as those made by the Manager itself, don't cause a profile to be applied
+// again. A profile that fails to apply isn't tried again until the outputs
+// change or SetProfiles is called, since applying it causes output events.
+//
+//	m := profile.NewManager(client, profiles, func(ctx context.Context, m *profile.Match, err error) {
+//		...
+//	})
+//
+//	return m.Run(ctx, 5*time.Second)
+type Manager struct {
+	sway.EventHandler
+
+	client sway.Client
+	fn     ApplyFunc
+
+	mu       sync.Mutex
+	profiles []*Profile
+	current  string
+}
+
+// NewManager returns a Manager that applies profiles using c and calls fn after
+// each attempt
+func NewManager(c sway.Client, profiles []*Profile, fn ApplyFunc) *Manager {
+	return &Manager{
+		EventHandler: sway.NoOpEventHandler(),
+		client:       c,
+		fn:           fn,
+		profiles:     profiles,
+	}
+}
+
+// SetProfiles replaces the profiles and applies the one that matches the
+// connected outputs, even if they haven't changed
+func (m *Manager) SetProfiles(ctx context.Context, profiles []*Profile) {
+	m.mu.Lock()
+	m.profiles = profiles
+	m.current = ""
+	m.mu.Unlock()
+
+	m.Update(ctx)
+}
+
+// Output implements sway.OutputHandler and calls Update
+func (m *Manager) Output(ctx context.Context, _ sway.OutputEvent) {
+	m.Update(ctx)
+}
+
+// Update applies the matching profile if the set of connected outputs changed
+// since the last update
+func (m *Manager) Update(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	outputs, err := m.client.GetOutputs(ctx)
+	if err != nil {
+		m.fn(ctx, nil, err)
+		return
+	}
+
+	key := outputsKey(outputs)
+	if key == m.current {
+		return
+	}
+
+	// the key is recorded even if applying the profile fails, since the
+	// failure would most likely repeat for each event the attempt causes
+	m.current = key
+
+	match := MatchOutputs(m.profiles, outputs)
+	if match == nil {
+		m.fn(ctx, nil, fmt.Errorf("no profile matches outputs %s", outputNames(outputs)))
+		return
+	}
+
+	m.fn(ctx, match, match.Apply(ctx, m.client))
+}
+
+// outputsKey identifies the set of connected outputs
+func outputsKey(outputs []sway.Output) string {
+	var ids []string
+	for i := range outputs {
+		if !outputs[i].NonDesktop {
+			ids = append(ids, outputs[i].Name+"\x00"+description(&outputs[i]))
+		}
+	}
+
+	sort.Strings(ids)
+
+	return strings.Join(ids, "\n")
+}
+
+// Run applies the matching profile and then updates it whenever sway sends an
+// output event, until ctx is canceled or the connection to sway fails. If sway
+// does not support output events, the outputs are polled at interval instead.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) error {
+	m.Update(ctx)
+
+	err := sway.Subscribe(ctx, m, sway.EventTypeOutput)
+	if err != sway.ErrSubscribeUnsuccessful {
+		return err
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			m.Update(ctx)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package profile
+
+import (
+	sway "github.com/joshuarubin/go-sway"
+)
+
+// A Match is a profile together with the outputs its settings apply to
+type Match struct {
+	Profile *Profile
+
+	// Outputs maps the names of the connected outputs to the settings that
+	// matched them
+	Outputs map[string]*Output
+
+	outputs []sway.Output
+}
+
+// MatchOutputs returns the first profile that matches the outputs, or nil if
+// none of them do. Like kanshi, a profile matches if each of its outputs
+// matches a different output and every output is matched, so a profile with
+// two "*" outputs matches any two outputs. Non-desktop outputs are ignored.
+func MatchOutputs(profiles []*Profile, outputs []sway.Output) *Match {
+	var connected []sway.Output
+	for _, o := range outputs {
+		if !o.NonDesktop {
+			connected = append(connected, o)
+		}
+	}
+
+	for _, p := range profiles {
+		if m := p.match(connected); m != nil {
+			return &Match{Profile: p, Outputs: m, outputs: connected}
+		}
+	}
+
+	return nil
+}
+
+func (p *Profile) match(outputs []sway.Output) map[string]*Output {
+	if len(p.Outputs) != len(outputs) {
+		return nil
+	}
+
+	ret := map[string]*Output{}
+
+	// assign finds an output for each of the settings from i on, backtracking
+	// when an output is needed by later settings
+	var assign func(i int) bool
+	assign = func(i int) bool {
+		if i == len(p.Outputs) {
+			return true
+		}
+
+		for j := range outputs {
+			name := outputs[j].Name
+			if ret[name] != nil || !p.Outputs[i].Matches(&outputs[j]) {
+				continue
+			}
+
+			ret[name] = p.Outputs[i]
+			if assign(i + 1) {
+				return true
+			}
+			delete(ret, name)
+		}
+
+		return false
+	}
+
+	if !assign(0) {
+		return nil
+	}
+
+	return ret
+}
+
+// output returns the name of the connected output matched by criteria, which
+// is either the criteria of one of the outputs of the profile or any other
+// output criteria
+func (m *Match) output(criteria string) (string, bool) {
+	for _, o := range m.outputs {
+		if s := m.Outputs[o.Name]; s != nil && s.Criteria == criteria {
+			return o.Name, true
+		}
+	}
+
+	c := Output{Criteria: criteria}
+	for i := range m.outputs {
+		if c.Matches(&m.outputs[i]) {
+			return m.outputs[i].Name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,322 @@
+// Package profile applies display profiles, in the style of kanshi, when the
+// set of connected outputs changes. Profiles are read from a file that uses
+// the sway config syntax:
+//
+//	profile docked {
+//		output eDP-1 disable
+//		output "Dell Inc. DELL U2720Q 1234ABC" mode 3840x2160@60Hz position 0,0 scale 1.5
+//		workspace 1 output "Dell Inc. DELL U2720Q 1234ABC"
+//	}
+//
+//	profile undocked {
+//		output eDP-1 enable scale 1.25
+//	}
+//
+// The first profile that matches all of the connected outputs is applied.
+package profile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sway "github.com/joshuarubin/go-sway"
+	"github.com/joshuarubin/go-sway/config"
+)
+
+// A Profile is a set of output settings and workspace assignments that is
+// applied when its outputs are the ones that are connected
+type Profile struct {
+	Name       string
+	Outputs    []*Output
+	Workspaces []Workspace
+	Pos        config.Pos
+}
+
+// Output holds the settings for the outputs matched by Criteria. Settings that
+// aren't set are left as they are.
+type Output struct {
+	// Criteria is "*", which matches any output, the name of the output, e.g.
+	// "eDP-1", its description "<make> <model> <serial>", or one of make:<make>,
+	// model:<model> or serial:<serial>
+	Criteria string
+
+	Enabled      *bool
+	Mode         string
+	Position     *sway.Point
+	Scale        float64
+	Transform    sway.Transform
+	AdaptiveSync *bool
+
+	Pos config.Pos
+}
+
+// A Workspace is assigned to the output matched by Output, which is either the
+// criteria of one of the outputs of the profile or another output criteria.
+// The workspace is moved to the output if it exists.
+type Workspace struct {
+	Name   string
+	Output string
+	Pos    config.Pos
+}
+
+// Load reads the profiles from the file at path
+func Load(path string) ([]*Profile, error) {
+	f, err := config.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return profiles(f)
+}
+
+// Parse parses the profiles in src, the contents of the file called name
+func Parse(name, src string) ([]*Profile, error) {
+	f, err := config.Parse(name, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return profiles(f)
+}
+
+func profiles(f *config.File) ([]*Profile, error) {
+	vars := f.Vars()
+
+	var (
+		ret []*Profile
+		err error
+	)
+
+	blocks := map[*config.Statement]*Profile{}
+
+	f.Walk(func(s *config.Statement, parents []*config.Statement) bool {
+		if err != nil || s.Comment || s.Name == "set" || s.Name == "include" {
+			return err == nil
+		}
+
+		args := s.Args()
+		for i := range args {
+			args[i] = config.Expand(args[i], vars)
+		}
+
+		switch {
+		case len(parents) == 0 && s.Name == "profile":
+			if !s.IsBlock() {
+				err = &config.Error{Pos: s.Pos, Msg: "profile must be a block"}
+				return false
+			}
+
+			p := &Profile{Pos: s.Pos}
+			if len(args) > 0 {
+				p.Name = args[0]
+			}
+
+			blocks[s] = p
+			ret = append(ret, p)
+		case len(parents) == 1 && blocks[parents[0]] != nil:
+			err = blocks[parents[0]].parse(s, args)
+		default:
+			err = &config.Error{Pos: s.Pos, Msg: fmt.Sprintf("unexpected %q", s.Name)}
+		}
+
+		return err == nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (p *Profile) parse(s *config.Statement, args []string) error {
+	switch s.Name {
+	case "output":
+		o, err := parseOutput(s, args)
+		if err != nil {
+			return err
+		}
+		p.Outputs = append(p.Outputs, o)
+	case "workspace":
+		if len(args) != 3 || args[1] != "output" {
+			return &config.Error{Pos: s.Pos, Msg: "expected workspace <name> output <output>"}
+		}
+		p.Workspaces = append(p.Workspaces, Workspace{Name: args[0], Output: args[2], Pos: s.Pos})
+	default:
+		return &config.Error{Pos: s.Pos, Msg: fmt.Sprintf("unexpected %q in profile", s.Name)}
+	}
+
+	return nil
+}
+
+func parseOutput(s *config.Statement, args []string) (*Output, error) {
+	if len(args) == 0 {
+		return nil, &config.Error{Pos: s.Pos, Msg: "output requires a name or description"}
+	}
+
+	o := &Output{Criteria: args[0], Pos: s.Pos}
+
+	for i := 1; i < len(args); i++ {
+		pos := s.Words[i].Pos
+		errorf := func(format string, a ...interface{}) error {
+			return &config.Error{Pos: pos, Msg: fmt.Sprintf(format, a...)}
+		}
+
+		arg := func() (string, error) {
+			if i+1 >= len(args) {
+				return "", errorf("%s requires an argument", args[i])
+			}
+			i++
+			return args[i], nil
+		}
+
+		switch setting := args[i]; setting {
+		case "enable", "disable":
+			enabled := setting == "enable"
+			o.Enabled = &enabled
+		case "mode":
+			mode, err := arg()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err = sway.ParseOutputMode(mode); err != nil {
+				return nil, errorf("%v", err)
+			}
+
+			o.Mode = mode
+		case "position", "pos":
+			v, err := arg()
+			if err != nil {
+				return nil, err
+			}
+
+			// the position is either "x,y" or "x y"
+			if !strings.Contains(v, ",") && i+1 < len(args) {
+				i++
+				v += "," + args[i]
+			}
+
+			p, err := parsePoint(v)
+			if err != nil {
+				return nil, errorf("invalid position %q", v)
+			}
+
+			o.Position = &p
+		case "scale":
+			v, err := arg()
+			if err != nil {
+				return nil, err
+			}
+
+			if o.Scale, err = strconv.ParseFloat(v, 64); err != nil || o.Scale <= 0 {
+				return nil, errorf("invalid scale %q", v)
+			}
+		case "transform":
+			v, err := arg()
+			if err != nil {
+				return nil, err
+			}
+
+			if o.Transform = sway.Transform(v); !o.Transform.IsKnown() {
+				return nil, errorf("invalid transform %q", v)
+			}
+		case "adaptive_sync":
+			v, err := arg()
+			if err != nil {
+				return nil, err
+			}
+
+			if v != "on" && v != "off" {
+				return nil, errorf("adaptive_sync must be on or off")
+			}
+
+			enabled := v == "on"
+			o.AdaptiveSync = &enabled
+		default:
+			return nil, errorf("unknown output setting %q", setting)
+		}
+	}
+
+	return o, nil
+}
+
+func parsePoint(s string) (sway.Point, error) {
+	i := strings.IndexByte(s, ',')
+	if i < 0 {
+		return sway.Point{}, fmt.Errorf("invalid point")
+	}
+
+	x, err := strconv.ParseInt(s[:i], 10, 64)
+	if err != nil {
+		return sway.Point{}, err
+	}
+
+	y, err := strconv.ParseInt(s[i+1:], 10, 64)
+	if err != nil {
+		return sway.Point{}, err
+	}
+
+	return sway.Point{X: x, Y: y}, nil
+}
+
+// Matches returns true if the criteria of o match the output
+func (o *Output) Matches(out *sway.Output) bool {
+	c := o.Criteria
+
+	switch {
+	case c == "*":
+		return true
+	case strings.HasPrefix(c, "make:"):
+		return out.Make == c[len("make:"):]
+	case strings.HasPrefix(c, "model:"):
+		return out.Model == c[len("model:"):]
+	case strings.HasPrefix(c, "serial:"):
+		return out.Serial == c[len("serial:"):]
+	}
+
+	return c == out.Name || c == description(out)
+}
+
+// description returns the identifier sway uses for an output in output
+// commands, "<make> <model> <serial>"
+func description(o *sway.Output) string {
+	return fmt.Sprintf("%s %s %s", o.Make, o.Model, o.Serial)
+}
+
+// options returns the settings of o for ConfigureOutput
+func (o *Output) options() []sway.OutputOption {
+	var opts []sway.OutputOption
+
+	if o.Enabled != nil {
+		opts = append(opts, sway.WithOutputEnabled(*o.Enabled))
+	}
+
+	// the other settings can't be confirmed on disabled outputs
+	if o.Enabled != nil && !*o.Enabled {
+		return opts
+	}
+
+	if o.Mode != "" {
+		opts = append(opts, sway.WithOutputMode(o.Mode))
+	}
+
+	if o.Position != nil {
+		opts = append(opts, sway.WithOutputPosition(o.Position.X, o.Position.Y))
+	}
+
+	if o.Scale != 0 {
+		opts = append(opts, sway.WithOutputScale(o.Scale))
+	}
+
+	if o.Transform != "" {
+		opts = append(opts, sway.WithOutputTransform(o.Transform))
+	}
+
+	if o.AdaptiveSync != nil {
+		opts = append(opts, sway.WithOutputAdaptiveSync(*o.AdaptiveSync))
+	}
+
+	return opts
+}
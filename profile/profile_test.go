@@ -0,0 +1,230 @@
+package profile_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+	"github.com/joshuarubin/go-sway/command"
+	"github.com/joshuarubin/go-sway/profile"
+)
+
+const profiles = `set $dell "Dell Inc. DELL U2720Q 1234ABC"
+
+profile docked {
+    output eDP-1 disable
+    output $dell mode 3840x2160@60Hz position 0,0 scale 1.5
+    workspace 1 output $dell
+}
+
+profile dual {
+    output eDP-1 enable
+    output * position 1920 0
+}
+
+profile undocked {
+    output eDP-1 enable scale 1.25 transform normal adaptive_sync on
+}
+`
+
+var (
+	laptop = sway.Output{Name: "eDP-1", Make: "BOE", Model: "0x0BCA", Serial: "Unknown", Active: true}
+	dell   = sway.Output{Name: "DP-1", Make: "Dell Inc.", Model: "DELL U2720Q", Serial: "1234ABC", Active: true}
+	hdmi   = sway.Output{Name: "HDMI-A-1", Make: "Goldstar", Model: "LG TV", Serial: "1", Active: true}
+	vr     = sway.Output{Name: "DP-2", NonDesktop: true}
+)
+
+func TestParse(t *testing.T) {
+	ps, err := profile.Parse("profiles", profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ps) != 3 {
+		t.Fatalf("got %d profiles, want 3", len(ps))
+	}
+
+	docked := ps[0]
+	if docked.Name != "docked" || len(docked.Outputs) != 2 || len(docked.Workspaces) != 1 {
+		t.Fatalf("unexpected profile %+v", docked)
+	}
+
+	o := docked.Outputs[1]
+	want := profile.Output{
+		Criteria: "Dell Inc. DELL U2720Q 1234ABC",
+		Mode:     "3840x2160@60Hz",
+		Position: &sway.Point{},
+		Scale:    1.5,
+		Pos:      o.Pos,
+	}
+
+	if !reflect.DeepEqual(*o, want) {
+		t.Errorf("got %+v, want %+v", *o, want)
+	}
+
+	if ws := docked.Workspaces[0]; ws.Name != "1" || ws.Output != want.Criteria {
+		t.Errorf("unexpected workspace %+v", ws)
+	}
+
+	if p := ps[1].Outputs[1].Position; p == nil || *p != (sway.Point{X: 1920}) {
+		t.Errorf("got position %v, want 1920,0", p)
+	}
+
+	for src, msg := range map[string]string{
+		"output eDP-1 enable":                    `p:1:1: unexpected "output"`,
+		"profile p {\n    output eDP-1 dpms\n}":  `p:2:18: unknown output setting "dpms"`,
+		"profile p {\n    output eDP-1 scale\n}": `p:2:18: scale requires an argument`,
+		"profile p {\n    workspace 1 eDP-1\n}":  `p:2:5: expected workspace <name> output <output>`,
+	} {
+		_, err := profile.Parse("p", src)
+		if err == nil || err.Error() != msg {
+			t.Errorf("%q: got error %v, want %s", src, err, msg)
+		}
+	}
+}
+
+func TestMatchOutputs(t *testing.T) {
+	ps, err := profile.Parse("profiles", profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		outputs []sway.Output
+		want    string
+	}{
+		{[]sway.Output{laptop, dell}, "docked"},
+		{[]sway.Output{dell, laptop, vr}, "docked"},
+		{[]sway.Output{laptop, hdmi}, "dual"},
+		{[]sway.Output{laptop}, "undocked"},
+		{[]sway.Output{hdmi}, ""},
+	} {
+		m := profile.MatchOutputs(ps, tc.outputs)
+
+		var got string
+		if m != nil {
+			got = m.Profile.Name
+		}
+
+		if got != tc.want {
+			t.Errorf("%d outputs: got profile %q, want %q", len(tc.outputs), got, tc.want)
+		}
+	}
+
+	m := profile.MatchOutputs(ps, []sway.Output{laptop, hdmi})
+	if o := m.Outputs["HDMI-A-1"]; o == nil || o.Criteria != "*" {
+		t.Errorf("HDMI-A-1 matched %+v", o)
+	}
+
+	c := profile.Output{Criteria: "serial:1234ABC"}
+	if !c.Matches(&dell) || c.Matches(&laptop) {
+		t.Error("serial criteria did not match")
+	}
+}
+
+type fakeClient struct {
+	sway.Client
+	outputs    []sway.Output
+	workspaces []sway.Workspace
+	commands   []string
+	fail       int
+}
+
+func (c *fakeClient) GetOutputs(context.Context) ([]sway.Output, error) {
+	return c.outputs, nil
+}
+
+func (c *fakeClient) GetWorkspaces(context.Context) ([]sway.Workspace, error) {
+	return c.workspaces, nil
+}
+
+func (c *fakeClient) RunCommand(_ context.Context, cmd string) ([]sway.RunCommandReply, error) {
+	c.commands = append(c.commands, cmd)
+	if c.fail > 0 {
+		c.fail--
+		return nil, errors.New("connection failed")
+	}
+
+	replies := make([]sway.RunCommandReply, len(command.SplitCommands(cmd)))
+	for i := range replies {
+		replies[i].Success = true
+	}
+	return replies, nil
+}
+
+func TestManager(t *testing.T) {
+	ps, err := profile.Parse("profiles", `profile dual {
+    output eDP-1 enable
+    output * position 1920 0
+    workspace 2 output *
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hdmi := hdmi
+	hdmi.Rect = sway.Rect{X: 1920}
+
+	c := &fakeClient{
+		outputs: []sway.Output{laptop, hdmi},
+		workspaces: []sway.Workspace{
+			{Name: "1", Output: "eDP-1", Focused: true},
+			{Name: "2", Output: "eDP-1"},
+		},
+	}
+
+	var (
+		applied []string
+		errs    int
+	)
+
+	m := profile.NewManager(c, ps, func(_ context.Context, m *profile.Match, err error) {
+		if err != nil {
+			errs++
+			return
+		}
+		applied = append(applied, m.Profile.Name)
+	})
+
+	ctx := context.Background()
+
+	// a profile that keeps failing is not applied again for the output events
+	// that applying it causes
+	c.fail = 100
+	m.Update(ctx)
+
+	sent := len(c.commands)
+
+	m.Output(ctx, sway.OutputEvent{Change: "unspecified"})
+	m.Update(ctx)
+
+	if errs != 1 || len(applied) != 0 || len(c.commands) != sent {
+		t.Fatalf("got %d errors, applied %q, sent %q", errs, applied, c.commands)
+	}
+
+	// SetProfiles applies the profile again
+	c.fail = 0
+	c.commands = nil
+
+	m.SetProfiles(ctx, ps)
+	m.Output(ctx, sway.OutputEvent{Change: "unspecified"})
+
+	if want := []string{"dual"}; errs != 1 || !reflect.DeepEqual(applied, want) {
+		t.Errorf("applied %q, want %q", applied, want)
+	}
+
+	want := []string{
+		"output eDP-1 enable",
+		"output HDMI-A-1 position 1920 0",
+		"workspace 2 output HDMI-A-1",
+		"workspace --no-auto-back-and-forth 2",
+		"move workspace to output HDMI-A-1",
+		"workspace --no-auto-back-and-forth 1",
+	}
+
+	if !reflect.DeepEqual(c.commands, want) {
+		t.Errorf("sent %q, want %q", c.commands, want)
+	}
+}
@@ -0,0 +1,176 @@
+package sway
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TreeColumn selects a piece of information WriteTree prints for each node
+type TreeColumn int
+
+const (
+	// TreeColumnFocus marks the focused node with a "*"
+	TreeColumnFocus TreeColumn = iota
+
+	// TreeColumnType is the node type, e.g. "workspace" or "con"
+	TreeColumnType
+
+	// TreeColumnLayout is the node layout, e.g. "splith" or "tabbed"
+	TreeColumnLayout
+
+	// TreeColumnName is the quoted node name followed by the app_id, if set
+	TreeColumnName
+
+	// TreeColumnID is the node ID prefixed with "#"
+	TreeColumnID
+
+	// TreeColumnMarks lists the marks set on the node
+	TreeColumnMarks
+
+	// TreeColumnRect is the node geometry as WIDTHxHEIGHT+X+Y
+	TreeColumnRect
+)
+
+var defaultTreeColumns = []TreeColumn{
+	TreeColumnFocus,
+	TreeColumnType,
+	TreeColumnLayout,
+	TreeColumnName,
+	TreeColumnID,
+	TreeColumnMarks,
+	TreeColumnRect,
+}
+
+type treeRenderer struct {
+	columns   []TreeColumn
+	indent    string
+	output    string
+	workspace string
+}
+
+// TreeOption can be passed to WriteTree and TreeString to change what is
+// rendered
+type TreeOption func(*treeRenderer)
+
+// WithTreeColumns sets which columns are printed, and in which order. By
+// default, all columns are printed.
+func WithTreeColumns(columns ...TreeColumn) TreeOption {
+	return func(r *treeRenderer) {
+		r.columns = columns
+	}
+}
+
+// WithTreeIndent sets the string used to indent each level of the tree. It
+// defaults to two spaces.
+func WithTreeIndent(indent string) TreeOption {
+	return func(r *treeRenderer) {
+		r.indent = indent
+	}
+}
+
+// WithTreeOutput only renders the output with the given name
+func WithTreeOutput(name string) TreeOption {
+	return func(r *treeRenderer) {
+		r.output = name
+	}
+}
+
+// WithTreeWorkspace only renders the workspace with the given name
+func WithTreeWorkspace(name string) TreeOption {
+	return func(r *treeRenderer) {
+		r.workspace = name
+	}
+}
+
+// WriteTree writes the node hierarchy to w as an indented tree with one line
+// per node
+func (n *Node) WriteTree(w io.Writer, opts ...TreeOption) error {
+	r := treeRenderer{
+		columns: defaultTreeColumns,
+		indent:  "  ",
+	}
+
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	bw := bufio.NewWriter(w)
+	r.write(bw, n, 0)
+	return bw.Flush()
+}
+
+// TreeString returns the node hierarchy rendered as by WriteTree
+func (n *Node) TreeString(opts ...TreeOption) string {
+	var b strings.Builder
+	_ = n.WriteTree(&b, opts...)
+	return b.String()
+}
+
+func (r treeRenderer) skip(n *Node) bool {
+	switch n.Type {
+	case NodeOutput:
+		return r.output != "" && n.Name != r.output
+	case NodeWorkspace:
+		return r.workspace != "" && n.Name != r.workspace
+	}
+	return false
+}
+
+func (r treeRenderer) write(w *bufio.Writer, n *Node, depth int) {
+	if n == nil || r.skip(n) {
+		return
+	}
+
+	w.WriteString(strings.Repeat(r.indent, depth))
+	w.WriteString(r.line(n))
+	w.WriteByte('\n')
+
+	for _, child := range n.Nodes {
+		r.write(w, child, depth+1)
+	}
+
+	for _, child := range n.FloatingNodes {
+		r.write(w, child, depth+1)
+	}
+}
+
+func (r treeRenderer) line(n *Node) string {
+	fields := make([]string, 0, len(r.columns))
+
+	for _, col := range r.columns {
+		var field string
+
+		switch col {
+		case TreeColumnFocus:
+			if n.Focused {
+				field = "*"
+			}
+		case TreeColumnType:
+			field = string(n.Type)
+		case TreeColumnLayout:
+			field = string(n.Layout)
+		case TreeColumnName:
+			field = strconv.Quote(n.Name)
+			if n.AppID != nil && *n.AppID != "" {
+				field += " app_id=" + *n.AppID
+			}
+		case TreeColumnID:
+			field = "#" + strconv.FormatInt(n.ID, 10)
+		case TreeColumnMarks:
+			if len(n.Marks) > 0 {
+				field = "marks=[" + strings.Join(n.Marks, ",") + "]"
+			}
+		case TreeColumnRect:
+			field = fmt.Sprintf("%dx%d%+d%+d", n.Rect.Width, n.Rect.Height, n.Rect.X, n.Rect.Y)
+		}
+
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
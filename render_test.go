@@ -0,0 +1,307 @@
+package sway_test
+
+import (
+	"strings"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestTreeString(t *testing.T) {
+	kitty := "kitty"
+
+	root := &sway.Node{
+		ID:   1,
+		Name: "root",
+		Type: sway.NodeRoot,
+		Nodes: []*sway.Node{
+			{
+				ID:     3,
+				Name:   "eDP-1",
+				Type:   sway.NodeOutput,
+				Layout: sway.LayoutOutput,
+				Rect:   sway.Rect{Width: 1920, Height: 1080},
+				Nodes: []*sway.Node{
+					{
+						ID:     4,
+						Name:   "1",
+						Type:   sway.NodeWorkspace,
+						Layout: sway.LayoutSplitH,
+						Rect:   sway.Rect{Width: 1920, Height: 1080},
+						Nodes: []*sway.Node{
+							{
+								ID:      7,
+								Name:    "~",
+								Type:    sway.NodeCon,
+								Layout:  sway.LayoutNone,
+								AppID:   &kitty,
+								Marks:   []string{"a"},
+								Focused: true,
+								Rect:    sway.Rect{Width: 1920, Height: 1080},
+							},
+						},
+					},
+				},
+			},
+			{
+				ID:   5,
+				Name: "HDMI-A-1",
+				Type: sway.NodeOutput,
+			},
+		},
+	}
+
+	got := root.TreeString(
+		sway.WithTreeOutput("eDP-1"),
+		sway.WithTreeColumns(sway.TreeColumnType, sway.TreeColumnName, sway.TreeColumnFocus),
+	)
+
+	want := `root "root"
+  output "eDP-1"
+    workspace "1"
+      con "~" app_id=kitty *
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func renderTree() *sway.Node {
+	kitty, mpv := "kitty", "mpv"
+
+	return &sway.Node{
+		ID:     1,
+		Name:   "root",
+		Type:   sway.NodeRoot,
+		Layout: sway.LayoutSplitH,
+		Rect:   sway.Rect{Width: 1920, Height: 1080},
+		Nodes: []*sway.Node{
+			{
+				ID:     3,
+				Name:   "eDP-1",
+				Type:   sway.NodeOutput,
+				Layout: sway.LayoutOutput,
+				Rect:   sway.Rect{Width: 1920, Height: 1080},
+				Nodes: []*sway.Node{
+					{
+						ID:     4,
+						Name:   "1",
+						Type:   sway.NodeWorkspace,
+						Layout: sway.LayoutSplitH,
+						Rect:   sway.Rect{Y: 30, Width: 1920, Height: 1050},
+						Nodes: []*sway.Node{
+							{
+								ID:     7,
+								Name:   "~",
+								Type:   sway.NodeCon,
+								Layout: sway.LayoutNone,
+								AppID:  &kitty,
+								Marks:  []string{"a", "b"},
+								Rect:   sway.Rect{Y: 30, Width: 1920, Height: 1050},
+							},
+						},
+						FloatingNodes: []*sway.Node{
+							{
+								ID:     8,
+								Type:   sway.NodeFloatingCon,
+								Layout: sway.LayoutTabbed,
+								Rect:   sway.Rect{X: 100, Y: 130, Width: 800, Height: 600},
+								Nodes: []*sway.Node{
+									{
+										ID:      9,
+										Name:    "video",
+										Type:    sway.NodeCon,
+										Layout:  sway.LayoutNone,
+										AppID:   &mpv,
+										Focused: true,
+										Rect:    sway.Rect{X: 100, Y: 160, Width: 800, Height: 570},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				ID:     5,
+				Name:   "HDMI-A-1",
+				Type:   sway.NodeOutput,
+				Layout: sway.LayoutOutput,
+				Rect:   sway.Rect{X: 1920, Width: 2560, Height: 1440},
+				Nodes: []*sway.Node{
+					{
+						ID:     6,
+						Name:   "2",
+						Type:   sway.NodeWorkspace,
+						Layout: sway.LayoutSplitV,
+						Rect:   sway.Rect{X: 1920, Width: 2560, Height: 1440},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestWriteTree(t *testing.T) {
+	var b strings.Builder
+	if err := renderTree().WriteTree(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	// floating nodes follow the tiled ones, and their children are nested
+	// under them
+	want := `root splith "root" #1 1920x1080+0+0
+  output output "eDP-1" #3 1920x1080+0+0
+    workspace splith "1" #4 1920x1050+0+30
+      con none "~" app_id=kitty #7 marks=[a,b] 1920x1050+0+30
+      floating_con tabbed "" #8 800x600+100+130
+        * con none "video" app_id=mpv #9 800x570+100+160
+  output output "HDMI-A-1" #5 2560x1440+1920+0
+    workspace splitv "2" #6 2560x1440+1920+0
+`
+	if got := b.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTreeOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []sway.TreeOption
+		want string
+	}{
+		{
+			name: "focus",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnID, sway.TreeColumnFocus)},
+			want: `#1
+  #3
+    #4
+      #7
+      #8
+        #9 *
+  #5
+    #6
+`,
+		},
+		{
+			name: "type",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnType)},
+			want: `root
+  output
+    workspace
+      con
+      floating_con
+        con
+  output
+    workspace
+`,
+		},
+		{
+			name: "layout",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnLayout)},
+			want: `splith
+  output
+    splith
+      none
+      tabbed
+        none
+  output
+    splitv
+`,
+		},
+		{
+			name: "name",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnName)},
+			want: `"root"
+  "eDP-1"
+    "1"
+      "~" app_id=kitty
+      ""
+        "video" app_id=mpv
+  "HDMI-A-1"
+    "2"
+`,
+		},
+		{
+			name: "id",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnID)},
+			want: `#1
+  #3
+    #4
+      #7
+      #8
+        #9
+  #5
+    #6
+`,
+		},
+		{
+			name: "marks",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnID, sway.TreeColumnMarks)},
+			want: `#1
+  #3
+    #4
+      #7 marks=[a,b]
+      #8
+        #9
+  #5
+    #6
+`,
+		},
+		{
+			name: "rect",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnRect)},
+			want: `1920x1080+0+0
+  1920x1080+0+0
+    1920x1050+0+30
+      1920x1050+0+30
+      800x600+100+130
+        800x570+100+160
+  2560x1440+1920+0
+    2560x1440+1920+0
+`,
+		},
+		{
+			name: "order",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnID, sway.TreeColumnType)},
+			want: `#1 root
+  #3 output
+    #4 workspace
+      #7 con
+      #8 floating_con
+        #9 con
+  #5 output
+    #6 workspace
+`,
+		},
+		{
+			name: "indent",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnID), sway.WithTreeIndent("\t")},
+			want: "#1\n\t#3\n\t\t#4\n\t\t\t#7\n\t\t\t#8\n\t\t\t\t#9\n\t#5\n\t\t#6\n",
+		},
+		{
+			name: "output",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnID), sway.WithTreeOutput("HDMI-A-1")},
+			want: `#1
+  #5
+    #6
+`,
+		},
+		{
+			name: "workspace",
+			opts: []sway.TreeOption{sway.WithTreeColumns(sway.TreeColumnID), sway.WithTreeWorkspace("1")},
+			want: `#1
+  #3
+    #4
+      #7
+      #8
+        #9
+  #5
+`,
+		},
+	} {
+		if got := renderTree().TreeString(tc.opts...); got != tc.want {
+			t.Errorf("%s: got:\n%s\nwant:\n%s", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package sway
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Representation is the parsed form of the layout string sway provides in
+// Node.Representation, e.g. "H[V[firefox kitty] emacs]". Split containers have
+// a Layout and Nodes, leaves only have a Name.
+type Representation struct {
+	// The layout of a split container. Empty for leaves.
+	Layout Layout
+
+	// The identifier of a leaf, usually the view's class or app_id. sway uses
+	// "(null)" when a view has neither.
+	Name string
+
+	// The children of a split container
+	Nodes []*Representation
+}
+
+var representationLayouts = map[byte]Layout{
+	'H': LayoutSplitH,
+	'V': LayoutSplitV,
+	'T': LayoutTabbed,
+	'S': LayoutStacked,
+	'D': LayoutNone,
+}
+
+func representationPrefix(l Layout) (byte, bool) {
+	for k, v := range representationLayouts {
+		if v == l {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// IsLeaf returns true if r is a leaf rather than a split container
+func (r *Representation) IsLeaf() bool {
+	return r.Layout == ""
+}
+
+// String formats r the same way sway does
+func (r *Representation) String() string {
+	var b strings.Builder
+	r.write(&b)
+	return b.String()
+}
+
+func (r *Representation) write(b *strings.Builder) {
+	if r == nil {
+		return
+	}
+
+	if r.IsLeaf() {
+		b.WriteString(r.Name)
+		return
+	}
+
+	prefix, ok := representationPrefix(r.Layout)
+	if !ok {
+		prefix = 'D'
+	}
+
+	b.WriteByte(prefix)
+	b.WriteByte('[')
+	for i, n := range r.Nodes {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		n.write(b)
+	}
+	b.WriteByte(']')
+}
+
+// ParseRepresentation parses a representation string such as
+// "H[V[firefox kitty] emacs]" into a tree.
+//
+// sway does not quote the names of leaves, so a name that contains a space,
+// such as the class "Tor Browser", can't be told apart from two leaves and is
+// parsed as two. A name that contains "]" ends its container early, which
+// usually makes the string invalid. Use BuildRepresentation when the Node is
+// available.
+func ParseRepresentation(s string) (*Representation, error) {
+	p := representationParser{s: s}
+
+	r, err := p.parseNode()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos])
+	}
+
+	return r, nil
+}
+
+type representationParser struct {
+	s   string
+	pos int
+}
+
+func (p *representationParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("representation %q: offset %d: %s", p.s, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *representationParser) parseNode() (*Representation, error) {
+	if p.pos+1 < len(p.s) && p.s[p.pos+1] == '[' {
+		if l, ok := representationLayouts[p.s[p.pos]]; ok {
+			p.pos += 2
+			return p.parseContainer(l)
+		}
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] != ' ' && p.s[p.pos] != ']' {
+		p.pos++
+	}
+
+	if p.pos == start {
+		if p.pos == len(p.s) {
+			return nil, p.errorf("unexpected end of input")
+		}
+		return nil, p.errorf("unexpected %q", p.s[p.pos])
+	}
+
+	return &Representation{Name: p.s[start:p.pos]}, nil
+}
+
+func (p *representationParser) parseContainer(l Layout) (*Representation, error) {
+	r := &Representation{Layout: l}
+
+	for {
+		if p.pos == len(p.s) {
+			return nil, p.errorf("missing ']'")
+		}
+
+		if p.s[p.pos] == ']' {
+			p.pos++
+			return r, nil
+		}
+
+		if len(r.Nodes) > 0 {
+			if p.s[p.pos] != ' ' {
+				return nil, p.errorf("expected ' ' or ']'")
+			}
+			p.pos++
+		}
+
+		n, err := p.parseNode()
+		if err != nil {
+			return nil, err
+		}
+
+		r.Nodes = append(r.Nodes, n)
+	}
+}
+
+// BuildRepresentation creates the Representation for a workspace or container
+// from its tiling children, the same way sway builds Node.Representation
+func BuildRepresentation(n *Node) *Representation {
+	r := &Representation{Layout: n.Layout}
+	if _, ok := representationPrefix(r.Layout); !ok {
+		r.Layout = LayoutNone
+	}
+
+	for _, child := range n.Nodes {
+		if len(child.Nodes) > 0 {
+			r.Nodes = append(r.Nodes, BuildRepresentation(child))
+			continue
+		}
+
+		r.Nodes = append(r.Nodes, &Representation{Name: child.representationName()})
+	}
+
+	return r
+}
+
+func (n *Node) representationName() string {
+	if n.WindowProperties != nil && n.WindowProperties.Class != "" {
+		return n.WindowProperties.Class
+	}
+
+	if n.AppID != nil && *n.AppID != "" {
+		return *n.AppID
+	}
+
+	return "(null)"
+}
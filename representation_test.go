@@ -0,0 +1,88 @@
+package sway_test
+
+import (
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestParseRepresentation(t *testing.T) {
+	for _, s := range []string{
+		"H[]",
+		"H[emacs]",
+		"H[V[firefox kitty] emacs]",
+		"T[S[(null) foot] D[Alacritty]]",
+	} {
+		r, err := sway.ParseRepresentation(s)
+		if err != nil {
+			t.Fatalf("%q: %v", s, err)
+		}
+
+		if got := r.String(); got != s {
+			t.Errorf("round trip: got %q, want %q", got, s)
+		}
+	}
+
+	r, err := sway.ParseRepresentation("H[V[firefox kitty] emacs]")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Layout != sway.LayoutSplitH || len(r.Nodes) != 2 {
+		t.Fatalf("unexpected root: %+v", r)
+	}
+
+	if v := r.Nodes[0]; v.Layout != sway.LayoutSplitV || len(v.Nodes) != 2 || v.Nodes[1].Name != "kitty" {
+		t.Errorf("unexpected first child: %+v", v)
+	}
+
+	if e := r.Nodes[1]; !e.IsLeaf() || e.Name != "emacs" {
+		t.Errorf("unexpected second child: %+v", e)
+	}
+
+	// names are not quoted, so one with a space is read as two leaves
+	if r, err = sway.ParseRepresentation("H[Tor Browser]"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.Nodes) != 2 || r.Nodes[0].Name != "Tor" || r.Nodes[1].Name != "Browser" {
+		t.Errorf("unexpected leaves: %+v", r.Nodes)
+	}
+
+	for _, s := range []string{"", "H[", "H[a  b]", "H[a]]", "H[a]b", "H[a]b c]"} {
+		if _, err := sway.ParseRepresentation(s); err == nil {
+			t.Errorf("%q: expected error", s)
+		}
+	}
+}
+
+func TestBuildRepresentation(t *testing.T) {
+	firefox, kitty := "firefox", "kitty"
+
+	ws := &sway.Node{
+		Type:   sway.NodeWorkspace,
+		Layout: sway.LayoutSplitH,
+		Nodes: []*sway.Node{
+			{
+				Type:   sway.NodeCon,
+				Layout: sway.LayoutSplitV,
+				Nodes: []*sway.Node{
+					{Type: sway.NodeCon, AppID: &firefox},
+					{Type: sway.NodeCon, AppID: &kitty},
+				},
+			},
+			{Type: sway.NodeCon, WindowProperties: &sway.WindowProperties{Class: "Emacs"}},
+			{Type: sway.NodeCon, WindowProperties: &sway.WindowProperties{Class: "Tor Browser"}},
+		},
+	}
+
+	// names with spaces are kept as one leaf and written as sway does
+	r := sway.BuildRepresentation(ws)
+	if n := r.Nodes[2]; n.Name != "Tor Browser" {
+		t.Errorf("unexpected leaf %+v", n)
+	}
+
+	if got, want := r.String(), "H[V[firefox kitty] Emacs Tor Browser]"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
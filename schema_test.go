@@ -0,0 +1,254 @@
+package sway_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+var swayVersions = []string{"1.5", "1.10"}
+
+func loadFixture(t *testing.T, version, name string, v interface{}) bool {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(filepath.Join("testdata", version, name))
+	if err != nil {
+		return false
+	}
+
+	if err = json.Unmarshal(data, v); err != nil {
+		t.Fatalf("%s/%s: %v", version, name, err)
+	}
+
+	return true
+}
+
+// loadOutputs loads the outputs of the 1.10 fixture, eDP-1 and DP-1
+func loadOutputs(t *testing.T) []sway.Output {
+	t.Helper()
+
+	var outputs []sway.Output
+	if !loadFixture(t, "1.10", "get_outputs.json", &outputs) {
+		t.Fatal("missing fixture")
+	}
+
+	if len(outputs) != 2 || outputs[0].Name != "eDP-1" || outputs[1].Name != "DP-1" {
+		t.Fatalf("expected eDP-1 and DP-1 in the fixture, got %d outputs", len(outputs))
+	}
+
+	return outputs
+}
+
+// checkExtra fails if sway sent fields that are not modeled, so that fixtures
+// from new versions of sway show what has to be added
+func checkExtra(t *testing.T, what string, extra map[string]json.RawMessage) {
+	t.Helper()
+
+	if len(extra) > 0 {
+		names := make([]string, 0, len(extra))
+		for k := range extra {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		t.Errorf("%s: %q are not modeled", what, names)
+	}
+}
+
+// decodeExtra decodes the fields in extra into v. sway describes outputs and
+// workspaces as nodes, so the fields that aren't modeled by one of the types
+// have to be modeled by the other.
+func decodeExtra(t *testing.T, extra map[string]json.RawMessage, v interface{}) {
+	t.Helper()
+
+	data, err := json.Marshal(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkNode(t *testing.T, what string, n *sway.Node) {
+	t.Helper()
+
+	n.TraverseNodes(func(n *sway.Node) bool {
+		extra := n.Extra
+
+		if n.Type == sway.NodeOutput {
+			var o sway.Output
+			decodeExtra(t, extra, &o)
+			extra = o.Extra
+		}
+
+		checkExtra(t, what+" "+string(n.Type)+" "+n.Name, extra)
+		return false
+	})
+}
+
+func TestSchemaOutputs(t *testing.T) {
+	for _, version := range swayVersions {
+		var outputs []sway.Output
+		if !loadFixture(t, version, "get_outputs.json", &outputs) {
+			continue
+		}
+
+		for _, o := range outputs {
+			var n sway.Node
+			decodeExtra(t, o.Extra, &n)
+			checkExtra(t, version+" output "+o.Name, n.Extra)
+
+			if o.CurrentMode.Width == 0 || len(o.Modes) == 0 {
+				t.Errorf("%s output %s: modes not decoded", version, o.Name)
+			}
+		}
+	}
+
+	outputs := loadOutputs(t)
+
+	if edp := outputs[0]; edp.ScaleFilter != sway.ScaleFilterNearest || !edp.ScaleFilter.IsKnown() || !edp.Focused {
+		t.Errorf("unexpected eDP-1: %+v", edp)
+	}
+
+	if dp := outputs[1]; !dp.Power || dp.AdaptiveSyncStatus != "enabled" || dp.MaxRenderTime != 7 || !dp.AllowTearing || dp.Focused {
+		t.Errorf("unexpected DP-1: %+v", dp)
+	}
+
+	if len(outputs[1].Modes) < 2 {
+		t.Fatalf("DP-1: got %d modes", len(outputs[1].Modes))
+	}
+
+	if got := outputs[1].Modes[1].PictureAspectRatio; got != "16:9" {
+		t.Errorf("picture_aspect_ratio: got %q", got)
+	}
+}
+
+func TestSchemaWorkspaces(t *testing.T) {
+	var workspaces []sway.Workspace
+	if !loadFixture(t, "1.10", "get_workspaces.json", &workspaces) {
+		t.Fatal("missing fixture")
+	}
+
+	var outputs []sway.Output
+	if !loadFixture(t, "1.10", "get_outputs.json", &outputs) {
+		t.Fatal("missing fixture")
+	}
+
+	for _, ws := range workspaces {
+		var n sway.Node
+		decodeExtra(t, ws.Extra, &n)
+		checkExtra(t, "workspace "+ws.Name, n.Extra)
+
+		for _, f := range ws.FloatingNodes {
+			checkNode(t, "workspace "+ws.Name, f)
+		}
+
+		// the fixtures have to agree with each other
+		for _, o := range outputs {
+			if o.Name != ws.Output {
+				continue
+			}
+
+			if visible := o.CurrentWorkspace == ws.Name; ws.Visible != visible || ws.Focused != (visible && o.Focused) {
+				t.Errorf("workspace %s: visible %v and focused %v disagree with output %s", ws.Name, ws.Visible, ws.Focused, o.Name)
+			}
+		}
+	}
+
+	if len(workspaces) == 0 {
+		t.Fatal("no workspaces in the fixture")
+	}
+
+	ws := workspaces[0]
+	if ws.Layout != sway.LayoutSplitH || ws.Representation == nil || *ws.Representation != "H[jetbrains-goland]" {
+		t.Errorf("unexpected workspace: %+v", ws)
+	}
+
+	if len(ws.FloatingNodes) != 1 || ws.FloatingNodes[0].Floating == nil || *ws.FloatingNodes[0].Floating != "user_on" {
+		t.Fatalf("unexpected floating nodes: %+v", ws.FloatingNodes)
+	}
+}
+
+func TestSchemaTree(t *testing.T) {
+	var root sway.Node
+	if !loadFixture(t, "1.10", "get_tree.json", &root) {
+		t.Fatal("missing fixture")
+	}
+
+	checkNode(t, "tree", &root)
+
+	view := root.FocusedNode()
+	if view == nil {
+		t.Fatal("no focused node")
+	}
+
+	if view.SandboxAppID == nil || *view.SandboxAppID != "com.jetbrains.GoLand" ||
+		view.ScratchpadState == nil || *view.ScratchpadState != "none" ||
+		view.ForeignToplevelIdentifier == nil || view.MaxRenderTime == nil {
+		t.Errorf("unexpected view: %+v", view)
+	}
+
+	if len(root.Nodes) < 2 || len(root.Nodes[1].Nodes) == 0 {
+		t.Fatalf("expected a workspace on the first output, got %+v", root.Nodes)
+	}
+
+	ws := root.Nodes[1].Nodes[0]
+	if ws.Num == nil || *ws.Num != 1 || ws.Output == nil || *ws.Output != "eDP-1" {
+		t.Errorf("unexpected workspace: %+v", ws)
+	}
+}
+
+func TestSchemaInputs(t *testing.T) {
+	for _, version := range swayVersions {
+		var inputs []sway.Input
+		if !loadFixture(t, version, "get_inputs.json", &inputs) {
+			continue
+		}
+
+		for _, in := range inputs {
+			checkExtra(t, version+" input "+in.Identifier, in.Extra)
+
+			if in.LibInput != nil {
+				checkExtra(t, version+" libinput "+in.Identifier, in.LibInput.Extra)
+			}
+		}
+	}
+
+	var inputs []sway.Input
+	if !loadFixture(t, "1.10", "get_inputs.json", &inputs) {
+		t.Fatal("missing fixture")
+	}
+
+	if len(inputs) == 0 {
+		t.Fatal("no inputs in the fixture")
+	}
+
+	if tp := inputs[0]; tp.ScrollFactor == nil || *tp.ScrollFactor != 0.5 || tp.LibInput == nil || tp.LibInput.DWTP != "enabled" {
+		t.Errorf("unexpected touchpad: %+v", tp)
+	}
+}
+
+func TestEnumsKeepUnknownValues(t *testing.T) {
+	var o sway.Output
+	if err := json.Unmarshal([]byte(`{"transform":"flipped-90","subpixel_hinting":"diagonal"}`), &o); err != nil {
+		t.Fatal(err)
+	}
+
+	if o.Transform != sway.TransformFlipped90 || !o.Transform.IsKnown() {
+		t.Errorf("transform: got %q", o.Transform)
+	}
+
+	if o.SubpixelHinting.String() != "diagonal" || o.SubpixelHinting.IsKnown() {
+		t.Errorf("subpixel_hinting: got %q", o.SubpixelHinting)
+	}
+
+	if sway.Layout("grid").IsKnown() || !sway.LayoutTabbed.IsKnown() {
+		t.Error("unexpected Layout.IsKnown result")
+	}
+}
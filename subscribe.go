@@ -13,6 +13,10 @@ const (
 	// focus
 	EventTypeWorkspace EventType = "workspace"
 
+	// EventTypeOutput is sent when outputs are added, removed or changed. It is
+	// only passed to handlers that implement OutputHandler.
+	EventTypeOutput EventType = "output"
+
 	// EventTypeMode is sent whenever the binding mode changes
 	EventTypeMode EventType = "mode"
 
@@ -59,6 +63,14 @@ type EventHandler interface {
 	Input(context.Context, InputEvent)
 }
 
+// An OutputHandler is an EventHandler that also handles output events. It is
+// separate from EventHandler so that handlers written before output events
+// were supported keep working.
+type OutputHandler interface {
+	EventHandler
+	Output(context.Context, OutputEvent)
+}
+
 // NoOpEventHandler is used to help provide empty methods that aren't intended
 // to be handled by Subscribe
 //
@@ -98,17 +110,41 @@ func (h noOpEventHandler) Input(context.Context, InputEvent)
 
 // Subscribe the IPC connection to the events listed in the payload
 func Subscribe(ctx context.Context, handler EventHandler, events ...EventType) error {
-	n, err := New(ctx)
+	c, err := newSubscription(ctx, nil, events...)
 	if err != nil {
 		return err
 	}
 
-	c := n.(*client)
+	return c.handleEvents(ctx, handler)
+}
 
-	if err = c.subscribe(ctx, events...); err != nil {
-		return err
+// newSubscription subscribes a new connection to events. The connection is
+// made to the same socket as c, or to $SWAYSOCK if c is nil or was not created
+// by New. Events are queued on the connection until they are handled.
+func newSubscription(ctx context.Context, c Client, events ...EventType) (*client, error) {
+	var opts []Option
+	if cl, ok := c.(*client); ok {
+		opts = append(opts, WithSocketPath(cl.path))
+	}
+
+	n, err := New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := n.(*client)
+
+	if err = sub.subscribe(ctx, events...); err != nil {
+		_ = sub.conn.Close()
+		return nil, err
 	}
 
+	return sub, nil
+}
+
+// handleEvents passes the events received by c to handler until ctx is
+// canceled or the connection fails
+func (c *client) handleEvents(ctx context.Context, handler EventHandler) error {
 	for {
 		msg, err := c.recvMsg(ctx)
 		if err != nil {
@@ -120,12 +156,25 @@ func Subscribe(ctx context.Context, handler EventHandler, events ...EventType) e
 }
 
 func processEvent(ctx context.Context, h EventHandler, msg *message) {
+	// events that aren't decoded must still be read from the connection
+	defer msg.Payload.Close()
+
 	switch msg.Type {
 	case eventTypeWorkspace:
 		var e WorkspaceEvent
 		if err := msg.Decode(&e); err == nil {
 			h.Workspace(ctx, e)
 		}
+	case eventTypeOutput:
+		oh, ok := h.(OutputHandler)
+		if !ok {
+			return
+		}
+
+		var e OutputEvent
+		if err := msg.Decode(&e); err == nil {
+			oh.Output(ctx, e)
+		}
 	case eventTypeMode:
 		var e ModeEvent
 		if err := msg.Decode(&e); err == nil {
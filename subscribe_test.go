@@ -0,0 +1,72 @@
+package sway_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+type eventRecorder struct {
+	sway.EventHandler
+	events chan string
+}
+
+func (h eventRecorder) Workspace(_ context.Context, e sway.WorkspaceEvent) {
+	h.events <- "workspace " + string(e.Change)
+}
+
+type outputRecorder struct {
+	eventRecorder
+}
+
+func (h outputRecorder) Output(_ context.Context, e sway.OutputEvent) {
+	h.events <- "output " + e.Change
+}
+
+func TestSubscribeOutput(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		handler func(events chan string) sway.EventHandler
+		want    []string
+	}{{
+		name: "EventHandler",
+		handler: func(events chan string) sway.EventHandler {
+			return eventRecorder{EventHandler: sway.NoOpEventHandler(), events: events}
+		},
+		want: []string{"workspace focus"},
+	}, {
+		name: "OutputHandler",
+		handler: func(events chan string) sway.EventHandler {
+			return outputRecorder{eventRecorder{EventHandler: sway.NoOpEventHandler(), events: events}}
+		},
+		want: []string{"output unspecified", "workspace focus"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			f := newFakeSway(t, func(_ *fakeSway, typ uint32, _ string) string {
+				return `{"success": true}`
+			})
+
+			events := make(chan string, 2)
+			f.subscribe(ctx, t, tc.handler(events), sway.EventTypeOutput, sway.EventTypeWorkspace)
+
+			f.Event(ipcEventOutput, `{"change": "unspecified"}`)
+			f.Event(ipcEventWorkspace, `{"change": "focus"}`)
+
+			for _, want := range tc.want {
+				select {
+				case got := <-events:
+					if got != want {
+						t.Errorf("got %q, want %q", got, want)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("timed out waiting for %q", want)
+				}
+			}
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package sway
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 type Rect struct {
-	X      int64 `json:"x,omitempty"`
-	Y      int64 `json:"y,omitempty"`
-	Width  int64 `json:"width,omitempty"`
-	Height int64 `json:"height,omitempty"`
+	X      int64 `json:"x"`
+	Y      int64 `json:"y"`
+	Width  int64 `json:"width"`
+	Height int64 `json:"height"`
 }
 
 type WindowProperties struct {
@@ -15,7 +19,7 @@ type WindowProperties struct {
 	Instance     string `json:"instance,omitempty"`
 	Role         string `json:"window_role,omitempty"`
 	Type         string `json:"window_type,omitempty"`
-	TransientFor *int64 `json:"transient_for,omitempty"`
+	TransientFor *int64 `json:"transient_for"`
 }
 
 type IdleInhibitors struct {
@@ -34,6 +38,24 @@ const (
 	NodeFloatingCon NodeType = "floating_con"
 )
 
+// String implements fmt.Stringer
+func (t NodeType) String() string {
+	return string(t)
+}
+
+// IsKnown returns true if t is one of the NodeType values listed above
+func (t NodeType) IsKnown() bool {
+	switch t {
+	case NodeRoot,
+		NodeOutput,
+		NodeWorkspace,
+		NodeCon,
+		NodeFloatingCon:
+		return true
+	}
+	return false
+}
+
 // Node layouts
 type Layout string
 
@@ -43,8 +65,28 @@ const (
 	LayoutSplitV  Layout = "splitv"
 	LayoutStacked Layout = "stacked"
 	LayoutTabbed  Layout = "tabbed"
+	LayoutNone    Layout = "none"
 )
 
+// String implements fmt.Stringer
+func (l Layout) String() string {
+	return string(l)
+}
+
+// IsKnown returns true if l is one of the Layout values listed above
+func (l Layout) IsKnown() bool {
+	switch l {
+	case LayoutOutput,
+		LayoutSplitH,
+		LayoutSplitV,
+		LayoutStacked,
+		LayoutTabbed,
+		LayoutNone:
+		return true
+	}
+	return false
+}
+
 // Border types
 type Border string
 
@@ -55,6 +97,23 @@ const (
 	BorderCsd    Border = "csd"
 )
 
+// String implements fmt.Stringer
+func (b Border) String() string {
+	return string(b)
+}
+
+// IsKnown returns true if b is one of the Border values listed above
+func (b Border) IsKnown() bool {
+	switch b {
+	case BorderNormal,
+		BorderNone,
+		BorderPixel,
+		BorderCsd:
+		return true
+	}
+	return false
+}
+
 // Fullscreen modes
 type FullscreenMode int
 
@@ -64,6 +123,460 @@ const (
 	FullscreenGlobal FullscreenMode = 2
 )
 
+// IsKnown returns true if m is one of the FullscreenMode values listed above
+func (m FullscreenMode) IsKnown() bool {
+	switch m {
+	case FullscreenNone, FullscreenOutput, FullscreenGlobal:
+		return true
+	}
+	return false
+}
+
+// Node orientations
+type Orientation string
+
+const (
+	OrientationVertical   Orientation = "vertical"
+	OrientationHorizontal Orientation = "horizontal"
+	OrientationNone       Orientation = "none"
+)
+
+// String implements fmt.Stringer
+func (o Orientation) String() string {
+	return string(o)
+}
+
+// IsKnown returns true if o is one of the Orientation values listed above
+func (o Orientation) IsKnown() bool {
+	switch o {
+	case OrientationVertical,
+		OrientationHorizontal,
+		OrientationNone:
+		return true
+	}
+	return false
+}
+
+// Floating states, for i3 compatibility
+type FloatingState string
+
+const (
+	FloatingAutoOff FloatingState = "auto_off"
+	FloatingAutoOn  FloatingState = "auto_on"
+	FloatingUserOff FloatingState = "user_off"
+	FloatingUserOn  FloatingState = "user_on"
+)
+
+// String implements fmt.Stringer
+func (f FloatingState) String() string {
+	return string(f)
+}
+
+// IsKnown returns true if f is one of the FloatingState values listed above
+func (f FloatingState) IsKnown() bool {
+	switch f {
+	case FloatingAutoOff,
+		FloatingAutoOn,
+		FloatingUserOff,
+		FloatingUserOn:
+		return true
+	}
+	return false
+}
+
+// Scratchpad states
+type ScratchpadState string
+
+const (
+	ScratchpadNone    ScratchpadState = "none"
+	ScratchpadFresh   ScratchpadState = "fresh"
+	ScratchpadChanged ScratchpadState = "changed"
+)
+
+// String implements fmt.Stringer
+func (s ScratchpadState) String() string {
+	return string(s)
+}
+
+// IsKnown returns true if s is one of the ScratchpadState values listed above
+func (s ScratchpadState) IsKnown() bool {
+	switch s {
+	case ScratchpadNone,
+		ScratchpadFresh,
+		ScratchpadChanged:
+		return true
+	}
+	return false
+}
+
+// View shells
+type Shell string
+
+const (
+	ShellXDG      Shell = "xdg_shell"
+	ShellXWayland Shell = "xwayland"
+)
+
+// String implements fmt.Stringer
+func (s Shell) String() string {
+	return string(s)
+}
+
+// IsKnown returns true if s is one of the Shell values listed above
+func (s Shell) IsKnown() bool {
+	switch s {
+	case ShellXDG,
+		ShellXWayland:
+		return true
+	}
+	return false
+}
+
+// Output subpixel hinting
+type SubpixelHinting string
+
+const (
+	SubpixelUnknown SubpixelHinting = "unknown"
+	SubpixelNone    SubpixelHinting = "none"
+	SubpixelRGB     SubpixelHinting = "rgb"
+	SubpixelBGR     SubpixelHinting = "bgr"
+	SubpixelVRGB    SubpixelHinting = "vrgb"
+	SubpixelVBGR    SubpixelHinting = "vbgr"
+)
+
+// String implements fmt.Stringer
+func (h SubpixelHinting) String() string {
+	return string(h)
+}
+
+// IsKnown returns true if h is one of the SubpixelHinting values listed above
+func (h SubpixelHinting) IsKnown() bool {
+	switch h {
+	case SubpixelUnknown,
+		SubpixelNone,
+		SubpixelRGB,
+		SubpixelBGR,
+		SubpixelVRGB,
+		SubpixelVBGR:
+		return true
+	}
+	return false
+}
+
+// ScaleFilter is the filter used when an output is scaled
+type ScaleFilter string
+
+const (
+	ScaleFilterLinear  ScaleFilter = "linear"
+	ScaleFilterNearest ScaleFilter = "nearest"
+	ScaleFilterSmart   ScaleFilter = "smart"
+)
+
+// String implements fmt.Stringer
+func (f ScaleFilter) String() string {
+	return string(f)
+}
+
+// IsKnown returns true if f is one of the ScaleFilter values listed above
+func (f ScaleFilter) IsKnown() bool {
+	switch f {
+	case ScaleFilterLinear,
+		ScaleFilterNearest,
+		ScaleFilterSmart:
+		return true
+	}
+	return false
+}
+
+// Output transforms
+type Transform string
+
+const (
+	TransformNormal     Transform = "normal"
+	Transform90         Transform = "90"
+	Transform180        Transform = "180"
+	Transform270        Transform = "270"
+	TransformFlipped    Transform = "flipped"
+	TransformFlipped90  Transform = "flipped-90"
+	TransformFlipped180 Transform = "flipped-180"
+	TransformFlipped270 Transform = "flipped-270"
+)
+
+// String implements fmt.Stringer
+func (t Transform) String() string {
+	return string(t)
+}
+
+// IsKnown returns true if t is one of the Transform values listed above
+func (t Transform) IsKnown() bool {
+	switch t {
+	case TransformNormal,
+		Transform90,
+		Transform180,
+		Transform270,
+		TransformFlipped,
+		TransformFlipped90,
+		TransformFlipped180,
+		TransformFlipped270:
+		return true
+	}
+	return false
+}
+
+// Input device types
+type InputType string
+
+const (
+	InputKeyboard   InputType = "keyboard"
+	InputPointer    InputType = "pointer"
+	InputTouchpad   InputType = "touchpad"
+	InputTouch      InputType = "touch"
+	InputTabletTool InputType = "tablet_tool"
+	InputTabletPad  InputType = "tablet_pad"
+	InputSwitch     InputType = "switch"
+)
+
+// String implements fmt.Stringer
+func (t InputType) String() string {
+	return string(t)
+}
+
+// IsKnown returns true if t is one of the InputType values listed above
+func (t InputType) IsKnown() bool {
+	switch t {
+	case InputKeyboard,
+		InputPointer,
+		InputTouchpad,
+		InputTouch,
+		InputTabletTool,
+		InputTabletPad,
+		InputSwitch:
+		return true
+	}
+	return false
+}
+
+// Bar modes
+type BarMode string
+
+const (
+	BarModeDock      BarMode = "dock"
+	BarModeHide      BarMode = "hide"
+	BarModeInvisible BarMode = "invisible"
+	BarModeOverlay   BarMode = "overlay"
+)
+
+// String implements fmt.Stringer
+func (m BarMode) String() string {
+	return string(m)
+}
+
+// IsKnown returns true if m is one of the BarMode values listed above
+func (m BarMode) IsKnown() bool {
+	switch m {
+	case BarModeDock,
+		BarModeHide,
+		BarModeInvisible,
+		BarModeOverlay:
+		return true
+	}
+	return false
+}
+
+// Bar positions
+type BarPosition string
+
+const (
+	BarPositionTop    BarPosition = "top"
+	BarPositionBottom BarPosition = "bottom"
+)
+
+// String implements fmt.Stringer
+func (p BarPosition) String() string {
+	return string(p)
+}
+
+// IsKnown returns true if p is one of the BarPosition values listed above
+func (p BarPosition) IsKnown() bool {
+	switch p {
+	case BarPositionTop,
+		BarPositionBottom:
+		return true
+	}
+	return false
+}
+
+// Binding input types
+type BindingInputType string
+
+const (
+	BindingKeyboard BindingInputType = "keyboard"
+	BindingMouse    BindingInputType = "mouse"
+)
+
+// String implements fmt.Stringer
+func (t BindingInputType) String() string {
+	return string(t)
+}
+
+// IsKnown returns true if t is one of the BindingInputType values listed above
+func (t BindingInputType) IsKnown() bool {
+	switch t {
+	case BindingKeyboard,
+		BindingMouse:
+		return true
+	}
+	return false
+}
+
+// libinput states for options that can be turned on or off
+type LibInputState string
+
+const (
+	LibInputEnabled  LibInputState = "enabled"
+	LibInputDisabled LibInputState = "disabled"
+)
+
+// String implements fmt.Stringer
+func (s LibInputState) String() string {
+	return string(s)
+}
+
+// IsKnown returns true if s is one of the LibInputState values listed above
+func (s LibInputState) IsKnown() bool {
+	switch s {
+	case LibInputEnabled,
+		LibInputDisabled:
+		return true
+	}
+	return false
+}
+
+// libinput send events modes
+type SendEvents string
+
+const (
+	SendEventsEnabled                 SendEvents = "enabled"
+	SendEventsDisabled                SendEvents = "disabled"
+	SendEventsDisabledOnExternalMouse SendEvents = "disabled_on_external_mouse"
+)
+
+// String implements fmt.Stringer
+func (e SendEvents) String() string {
+	return string(e)
+}
+
+// IsKnown returns true if e is one of the SendEvents values listed above
+func (e SendEvents) IsKnown() bool {
+	switch e {
+	case SendEventsEnabled,
+		SendEventsDisabled,
+		SendEventsDisabledOnExternalMouse:
+		return true
+	}
+	return false
+}
+
+// libinput finger to button mappings
+type ButtonMap string
+
+const (
+	ButtonMapLMR ButtonMap = "lmr"
+	ButtonMapLRM ButtonMap = "lrm"
+)
+
+// String implements fmt.Stringer
+func (m ButtonMap) String() string {
+	return string(m)
+}
+
+// IsKnown returns true if m is one of the ButtonMap values listed above
+func (m ButtonMap) IsKnown() bool {
+	switch m {
+	case ButtonMapLMR,
+		ButtonMapLRM:
+		return true
+	}
+	return false
+}
+
+// libinput acceleration profiles
+type AccelProfile string
+
+const (
+	AccelProfileNone     AccelProfile = "none"
+	AccelProfileFlat     AccelProfile = "flat"
+	AccelProfileAdaptive AccelProfile = "adaptive"
+)
+
+// String implements fmt.Stringer
+func (p AccelProfile) String() string {
+	return string(p)
+}
+
+// IsKnown returns true if p is one of the AccelProfile values listed above
+func (p AccelProfile) IsKnown() bool {
+	switch p {
+	case AccelProfileNone,
+		AccelProfileFlat,
+		AccelProfileAdaptive:
+		return true
+	}
+	return false
+}
+
+// libinput click methods
+type ClickMethod string
+
+const (
+	ClickMethodNone        ClickMethod = "none"
+	ClickMethodButtonAreas ClickMethod = "button_areas"
+	ClickMethodClickFinger ClickMethod = "clickfinger"
+)
+
+// String implements fmt.Stringer
+func (m ClickMethod) String() string {
+	return string(m)
+}
+
+// IsKnown returns true if m is one of the ClickMethod values listed above
+func (m ClickMethod) IsKnown() bool {
+	switch m {
+	case ClickMethodNone,
+		ClickMethodButtonAreas,
+		ClickMethodClickFinger:
+		return true
+	}
+	return false
+}
+
+// libinput scroll methods
+type ScrollMethod string
+
+const (
+	ScrollMethodNone         ScrollMethod = "none"
+	ScrollMethodTwoFinger    ScrollMethod = "two_finger"
+	ScrollMethodEdge         ScrollMethod = "edge"
+	ScrollMethodOnButtonDown ScrollMethod = "on_button_down"
+)
+
+// String implements fmt.Stringer
+func (m ScrollMethod) String() string {
+	return string(m)
+}
+
+// IsKnown returns true if m is one of the ScrollMethod values listed above
+func (m ScrollMethod) IsKnown() bool {
+	switch m {
+	case ScrollMethodNone,
+		ScrollMethodTwoFinger,
+		ScrollMethodEdge,
+		ScrollMethodOnButtonDown:
+		return true
+	}
+	return false
+}
+
 // workspace event types
 type WorkspaceEventChange string
 
@@ -133,7 +646,7 @@ type Node struct {
 
 	// The node's orientation.
 	// It can be "vertical", "horizontal", or "none"
-	Orientation string `json:"orientation,omitempty"`
+	Orientation Orientation `json:"orientation,omitempty"`
 
 	// The percentage of the node's parent that it takes up or null for the root
 	// and other special nodes such as the scratchpad
@@ -179,10 +692,25 @@ type Node struct {
 	// that can be used as an aid in submitting reproduction steps for bug reports
 	Representation *string `json:"representation,omitempty"`
 
+	// (Only workspaces) The workspace number or -1 for workspaces that do not
+	// start with a number
+	Num *int64 `json:"num,omitempty"`
+
+	// (Only workspaces) The name of the output that the workspace is on
+	Output *string `json:"output,omitempty"`
+
 	// (Only containers and views) The fullscreen mode of the node.
 	// 0 means none, 1 means full output, and 2 means global fullscreen
 	FullscreenMode FullscreenMode `json:"fullscreen_mode,omitempty"`
 
+	// (Only containers and views) For i3 compatibility, whether the node is
+	// floating. It can be "auto_off", "auto_on", "user_off", or "user_on"
+	Floating *FloatingState `json:"floating,omitempty"`
+
+	// (Only containers and views) Whether the node is in the scratchpad.
+	// It can be "none", "fresh", or "changed"
+	ScratchpadState *ScratchpadState `json:"scratchpad_state,omitempty"`
+
 	// (Only views) For an xdg-shell view, the name of the application, if set.
 	// Otherwise, null
 	AppID *string `json:"app_id,omitempty"`
@@ -194,11 +722,31 @@ type Node struct {
 	Visible *bool `json:"visible,omitempty"`
 
 	// (Only views) The shell of the view, such as "xdg_shell" or "xwayland"
-	Shell *string `json:"shell,omitempty"`
+	Shell *Shell `json:"shell,omitempty"`
 
 	// (Only views) Whether the view is inhibiting the idle state
 	InhibitIdle *bool `json:"inhibit_idle,omitempty"`
 
+	// (Only views) The max render time in milliseconds or 0 when it is off
+	MaxRenderTime *int64 `json:"max_render_time,omitempty"`
+
+	// (Only views) Whether the view is allowed to tear
+	AllowTearing *bool `json:"allow_tearing,omitempty"`
+
+	// (Only views) The associated sandbox engine, such as "flatpak", if any
+	SandboxEngine *string `json:"sandbox_engine,omitempty"`
+
+	// (Only views) The app ID provided by the associated sandbox engine, if any
+	SandboxAppID *string `json:"sandbox_app_id,omitempty"`
+
+	// (Only views) The instance ID provided by the associated sandbox engine,
+	// if any
+	SandboxInstanceID *string `json:"sandbox_instance_id,omitempty"`
+
+	// (Only views) The identifier of the view in the
+	// ext-foreign-toplevel-list protocol
+	ForeignToplevelIdentifier *string `json:"foreign_toplevel_identifier,omitempty"`
+
 	// (Only views) An object containing the state of the application and user
 	// idle inhibitors. "application" can be "enabled" or "none".
 	// "user" can be "focus", "fullscreen", "open", "visible" or "none".
@@ -210,6 +758,11 @@ type Node struct {
 	// (Only xwayland views) An object containing the "title", "class", "instance",
 	// "window_role", "window_type", and "transient_for" for the view
 	WindowProperties *WindowProperties `json:"window_properties,omitempty"`
+
+	// Fields sent by sway that are not modeled by Node. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 // FocusedNode traverses the node tree and returns the focused node
@@ -263,6 +816,24 @@ type WorkspaceEvent struct {
 	// For a "focus" change, this is will be an object representing the workspace
 	// being switched from. Otherwise, it is null
 	Old *Node `json:"old,omitempty"`
+
+	// Fields sent by sway that are not modeled by WorkspaceEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
+}
+
+// OutputEvent is sent whenever outputs are added, removed or their
+// configuration changes. It does not say which output changed, use GetOutputs
+// to get the new state.
+type OutputEvent struct {
+	// The type of change that occurred. Currently this is always "unspecified"
+	Change string `json:"change,omitempty"`
+
+	// Fields sent by sway that are not modeled by OutputEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 // WindowEvent is sent whenever a change involving a view occurs
@@ -283,6 +854,11 @@ type WindowEvent struct {
 
 	// An object representing the view effected
 	Container Node `json:"container,omitempty"`
+
+	// Fields sent by sway that are not modeled by WindowEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 // ShutdownEvent is sent whenever the IPC is shutting down
@@ -290,11 +866,81 @@ type ShutdownEvent struct {
 	// A string containing the reason for the shutdown.  Currently, the only
 	// value for change is "exit", which is issued when sway is exiting.
 	Change string `json:"change,omitempty"`
+
+	// Fields sent by sway that are not modeled by ShutdownEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 type RunCommandReply struct {
 	Success bool   `json:"success,omitempty"`
 	Error   string `json:"error,omitempty"`
+
+	// Whether the command failed because it could not be parsed
+	ParseError bool `json:"parse_error,omitempty"`
+}
+
+// RunCommandResult maps a reply from RunCommands back to the command that
+// produced it
+type RunCommandResult struct {
+	// The index of the command string passed to RunCommands. A command string
+	// containing several commands produces one result for each of them.
+	Index int
+
+	// The single command the reply is for
+	Command string
+
+	// Whether sway ran the command. sway stops running commands after one
+	// that can't be parsed, so none of the following commands have a reply.
+	Ran bool
+
+	// The reply for the command, if it ran
+	Reply RunCommandReply
+}
+
+// CommandError describes a single failed command sent with RunCommands
+type CommandError struct {
+	// The index of the command string passed to RunCommands
+	Index int
+
+	// The single command that failed
+	Command string
+
+	// The error message from sway
+	Message string
+
+	// Whether the command failed because it could not be parsed
+	ParseError bool
+}
+
+func (e CommandError) Error() string {
+	if e.ParseError {
+		return fmt.Sprintf("command %d %q could not be parsed: %s", e.Index, e.Command, e.Message)
+	}
+	return fmt.Sprintf("command %d %q unsuccessful: %s", e.Index, e.Command, e.Message)
+}
+
+// CommandErrors is returned by RunCommands when any of the commands fail
+type CommandErrors []CommandError
+
+func (e CommandErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Indices returns the indices of the command strings that failed
+func (e CommandErrors) Indices() []int {
+	var ret []int
+	for _, err := range e {
+		if len(ret) == 0 || ret[len(ret)-1] != err.Index {
+			ret = append(ret, err.Index)
+		}
+	}
+	return ret
 }
 
 type Workspace struct {
@@ -321,23 +967,41 @@ type Workspace struct {
 
 	// The name of the output that the workspace is on
 	Output string `json:"output,omitempty"`
-}
 
-type Refresh float64
+	// The internal unique ID for the workspace node
+	ID int64 `json:"id,omitempty"`
 
-func (r *Refresh) UnmarshalJSON(raw []byte) error {
-	var n int64
-	if err := json.Unmarshal(raw, &n); err != nil {
-		return err
-	}
-	*r = Refresh(float64(n) / 1000)
-	return nil
+	// The workspace layout.
+	// It can be "splith", "splitv", "stacked", or "tabbed"
+	Layout Layout `json:"layout,omitempty"`
+
+	// The workspace orientation.
+	// It can be "vertical", "horizontal", or "none"
+	Orientation Orientation `json:"orientation,omitempty"`
+
+	// A string representation of the layout of the workspace
+	Representation *string `json:"representation,omitempty"`
+
+	// The floating children nodes for the workspace
+	FloatingNodes []*Node `json:"floating_nodes,omitempty"`
+
+	// Fields sent by sway that are not modeled by Workspace. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
+// Refresh is a refresh rate in mHz, the unit sway uses for it
+type Refresh int64
+
 type OutputMode struct {
 	Width   int64   `json:"width,omitempty"`
 	Height  int64   `json:"height,omitempty"`
 	Refresh Refresh `json:"refresh,omitempty"`
+
+	// The picture aspect ratio of the mode. It can be "none", "4:3", "16:9",
+	// "64:27", or "256:135"
+	PictureAspectRatio string `json:"picture_aspect_ratio,omitempty"`
 }
 
 type Output struct {
@@ -356,22 +1020,37 @@ type Output struct {
 	// Whether this output is active/enabled
 	Active bool `json:"active,omitempty"`
 
-	// Whether this output is on/off (via DPMS)
+	// Whether this output is on/off (via DPMS).
+	// Deprecated in sway in favor of Power
 	DPMS bool `json:"dpms,omitempty"`
 
+	// Whether this output is on/off
+	Power bool `json:"power,omitempty"`
+
+	// Whether this output is focused by the default seat (seat0)
+	Focused bool `json:"focused,omitempty"`
+
+	// Whether this output is a non-desktop output, such as a VR headset. Most
+	// other fields are omitted for non-desktop outputs
+	NonDesktop bool `json:"non_desktop,omitempty"`
+
 	// For i3 compatibility, this will be false. It does not make sense in Wayland
 	Primary bool `json:"primary,omitempty"`
 
 	// The scale currently in use on the output or -1 for disabled outputs
 	Scale float64 `json:"scale,omitempty"`
 
+	// The filter used when the output is scaled. This can be "linear",
+	// "nearest", or "smart"
+	ScaleFilter ScaleFilter `json:"scale_filter,omitempty"`
+
 	// The subpixel hinting current in use on the output.
 	// This can be "rgb", "bgr", "vrgb", "vbgr", or "none"
-	SubpixelHinting string `json:"subpixel_hinting,omitempty"`
+	SubpixelHinting SubpixelHinting `json:"subpixel_hinting,omitempty"`
 
 	// The transform currently in use for the output. This can be "normal", "90",
 	// "180", "270", "flipped-90", "flipped-180", or "flipped-270"
-	Transform string `json:"transform,omitempty"`
+	Transform Transform `json:"transform,omitempty"`
 
 	// The workspace currently visible on the output or null for disabled outputs
 	CurrentWorkspace string `json:"current_workspace,omitempty"`
@@ -385,6 +1064,20 @@ type Output struct {
 
 	// The bounds for the output consisting of "x", "y", "width", and "height"
 	Rect Rect `json:"rect,omitempty"`
+
+	// The status of adaptive sync. It can be "enabled" or "disabled"
+	AdaptiveSyncStatus string `json:"adaptive_sync_status,omitempty"`
+
+	// The max render time in milliseconds or 0 when it is off
+	MaxRenderTime int64 `json:"max_render_time,omitempty"`
+
+	// Whether the output is allowed to tear
+	AllowTearing bool `json:"allow_tearing,omitempty"`
+
+	// Fields sent by sway that are not modeled by Output. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 type BarConfigGaps struct {
@@ -394,80 +1087,80 @@ type BarConfigGaps struct {
 	Left   int64 `json:"left,omitempty"`
 }
 
-// The colors object contains strings which are all #RRGGBBAA representation
-// of the color
+// The colors object contains colors which are all #RRGGBBAA representations
+// of the color. Colors that sway did not send are nil.
 type BarConfigColors struct {
 	// The color to use for the bar background on unfocused outputs
-	Background string `json:"background,omitempty"`
+	Background *Color `json:"background,omitempty"`
 
 	// The color to use for the status line text on unfocused outputs
-	Statusline string `json:"statusline,omitempty"`
+	Statusline *Color `json:"statusline,omitempty"`
 
 	// 	The color to use for the separator text on unfocused outputs
-	Separator string `json:"separator,omitempty"`
+	Separator *Color `json:"separator,omitempty"`
 
 	// The color to use for the background of the bar on the focused output
-	FocusedBackground string `json:"focused_background,omitempty"`
+	FocusedBackground *Color `json:"focused_background,omitempty"`
 
 	// The color to use for the status line text on the focused output
-	FocusedStatusline string `json:"focused_statusline,omitempty"`
+	FocusedStatusline *Color `json:"focused_statusline,omitempty"`
 
 	// The color to use for the separator text on the focused output
-	FocusedSeparator string `json:"focused_separator,omitempty"`
+	FocusedSeparator *Color `json:"focused_separator,omitempty"`
 
 	// The color to use for the text of the focused workspace button
-	FocusedWorkspaceText string `json:"focused_workspace_text,omitempty"`
+	FocusedWorkspaceText *Color `json:"focused_workspace_text,omitempty"`
 
 	// The color to use for the background of the focused workspace button
-	FocusedWorkspaceBG string `json:"focused_workspace_bg,omitempty"`
+	FocusedWorkspaceBG *Color `json:"focused_workspace_bg,omitempty"`
 
 	// The color to use for the border of the focused workspace button
-	FocusedWorkspaceBorder string `json:"focused_workspace_border,omitempty"`
+	FocusedWorkspaceBorder *Color `json:"focused_workspace_border,omitempty"`
 
 	// The color to use for the text of the workspace buttons for the visible
 	// workspaces on unfocused outputs
-	ActiveWorkspaceText string `json:"active_workspace_text,omitempty"`
+	ActiveWorkspaceText *Color `json:"active_workspace_text,omitempty"`
 
 	// The color to use for the background of the workspace buttons for the
 	// visible workspaces on unfocused outputs
-	ActiveWorkspaceBG string `json:"active_workspace_bg,omitempty"`
+	ActiveWorkspaceBG *Color `json:"active_workspace_bg,omitempty"`
 
 	// The color to use for the border of the workspace buttons for the visible
 	// workspaces on unfocused outputs
-	ActiveWorkspaceBorder string `json:"active_workspace_border,omitempty"`
+	ActiveWorkspaceBorder *Color `json:"active_workspace_border,omitempty"`
 
 	// The color to use for the text of the workspace buttons for workspaces
 	// that are not visible
-	InactiveWorkspaceText string `json:"inactive_workspace_text,omitempty"`
+	InactiveWorkspaceText *Color `json:"inactive_workspace_text,omitempty"`
 
 	// The color to use for the background of the workspace buttons for workspaces
 	// that are not visible
-	InactiveWorkspaceBG string `json:"inactive_workspace_bg,omitempty"`
+	InactiveWorkspaceBG *Color `json:"inactive_workspace_bg,omitempty"`
 
 	// The color to use for the border of the workspace buttons for workspaces
 	// that are not visible
-	InactiveWorkspaceBorder string `json:"inactive_workspace_border,omitempty"`
+	InactiveWorkspaceBorder *Color `json:"inactive_workspace_border,omitempty"`
 
 	// The color to use for the text of the workspace buttons for workspaces
 	// that contain an urgent view
-	UrgentWorkspaceText string `json:"urgent_workspace_text,omitempty"`
+	UrgentWorkspaceText *Color `json:"urgent_workspace_text,omitempty"`
 
 	// The color to use for the background of the workspace buttons for workspaces
 	// that contain an urgent view
-	UrgentWorkspaceBG string `json:"urgent_workspace_bg,omitempty"`
+	UrgentWorkspaceBG *Color `json:"urgent_workspace_bg,omitempty"`
 
 	// The color to use for the border of the workspace buttons for workspaces
 	// that contain an urgent view
-	UrgentWorkspaceBorder string `json:"urgent_workspace_border,omitempty"`
+	UrgentWorkspaceBorder *Color `json:"urgent_workspace_border,omitempty"`
 
 	// The color to use for the text of the binding mode indicator
-	BindingModeText string `json:"binding_mode_text,omitempty"`
+	BindingModeText *Color `json:"binding_mode_text,omitempty"`
 
 	// The color to use for the background of the binding mode indicator
-	BindingModeBG string `json:"binding_mode_bg,omitempty"`
+	BindingModeBG *Color `json:"binding_mode_bg,omitempty"`
 
 	// The color to use for the border of the binding mode indicator
-	BindingModeBorder string `json:"binding_mode_border,omitempty"`
+	BindingModeBorder *Color `json:"binding_mode_border,omitempty"`
 }
 
 // BarConfigUpdateEvent is sent whenever a config for a bar changes. The event
@@ -480,10 +1173,10 @@ type BarConfig struct {
 	ID string `json:"id,omitempty"`
 
 	// The mode for the bar. It can be "dock", "hide", or "invisible"
-	Mode string `json:"mode,omitempty"`
+	Mode BarMode `json:"mode,omitempty"`
 
 	// The bar's position. It can currently either be "bottom" or "top"
-	Position string `json:"position,omitempty"`
+	Position BarPosition `json:"position,omitempty"`
 
 	// The command which should be run to generate the status line
 	StatusCommand string `json:"status_command,omitempty"`
@@ -519,6 +1212,11 @@ type BarConfig struct {
 
 	// The horizontal padding to use for the status line when at the end of an output
 	StatusEdgePadding int64 `json:"status_edge_padding,omitempty"`
+
+	// Fields sent by sway that are not modeled by BarConfig. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 // Contains version information about the sway process
@@ -538,6 +1236,11 @@ type Version struct {
 
 	// The path to the loaded config file
 	LoadedConfigFileName string `json:"loaded_config_file_name,omitempty"`
+
+	// Fields sent by sway that are not modeled by Version. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 type Config struct {
@@ -556,52 +1259,68 @@ type TickReply struct {
 type LibInput struct {
 	// Whether events are being sent by the device.
 	// It can be "enabled", "disabled", or "disabled_on_external_mouse"
-	SendEvents string `json:"send_events,omitempty"`
+	SendEvents SendEvents `json:"send_events,omitempty"`
 
 	// Whether tap to click is enabled. It can be "enabled" or "disabled"
-	Tap string `json:"tap,omitempty"`
+	Tap LibInputState `json:"tap,omitempty"`
 
 	// The finger to button mapping in use. It can be "lmr" or "lrm"
-	TapButtonMap string `json:"tap_button_map,omitempty"`
+	TapButtonMap ButtonMap `json:"tap_button_map,omitempty"`
 
 	// Whether tap-and-drag is enabled. It can be "enabled" or "disabled"
-	TapDrag string `json:"tap_drag,omitempty"`
+	TapDrag LibInputState `json:"tap_drag,omitempty"`
 
 	// Whether drag-lock is enabled. It can be "enabled" or "disabled"
-	TapDragLock string `json:"tap_drag_lock,omitempty"`
+	TapDragLock LibInputState `json:"tap_drag_lock,omitempty"`
 
 	// The pointer-acceleration in use
 	AccelSpeed float64 `json:"accel_speed,omitempty"`
 
 	// The acceleration profile in use. It can be "none", "flat", or "adaptive"
-	AccelProfile string `json:"accel_profile,omitempty"`
+	AccelProfile AccelProfile `json:"accel_profile,omitempty"`
 
 	// Whether natural scrolling is enabled. It can be "enabled" or "disabled"
-	NaturalScroll string `json:"natural_scroll,omitempty"`
+	NaturalScroll LibInputState `json:"natural_scroll,omitempty"`
 
 	// Whether left-handed mode is enabled. It can be "enabled" or "disabled"
-	LeftHanded string `json:"left_handed,omitempty"`
+	LeftHanded LibInputState `json:"left_handed,omitempty"`
 
 	// The click method in use. It can be "none", "button_areas", or "clickfinger"
-	ClickMethod string `json:"click_method,omitempty"`
+	ClickMethod ClickMethod `json:"click_method,omitempty"`
+
+	// The finger to button mapping in use for clickfinger. It can be "lmr" or
+	// "lrm"
+	ClickButtonMap ButtonMap `json:"click_button_map,omitempty"`
 
 	// Whether middle emulation is enabled. It can be "enabled" or "disabled"
-	MiddleEmulation string `json:"middle_emulation,omitempty"`
+	MiddleEmulation LibInputState `json:"middle_emulation,omitempty"`
 
 	// The scroll method in use.
 	// It can be "none", "two_finger", "edge", or "on_button_down"
-	ScrollMethod string `json:"scroll_method,omitempty"`
+	ScrollMethod ScrollMethod `json:"scroll_method,omitempty"`
 
 	// The scroll button to use when "scroll_method" is "on_button_down".
 	// This will be given as an input event code
 	ScrollButton int64 `json:"scroll_button,omitempty"`
 
+	// Whether scroll button lock is enabled. It can be "enabled" or "disabled"
+	ScrollButtonLock LibInputState `json:"scroll_button_lock,omitempty"`
+
 	// Whether disable-while-typing is enabled. It can be "enabled" or "disabled"
-	DWT string `json:"dwt,omitempty"`
+	DWT LibInputState `json:"dwt,omitempty"`
+
+	// Whether disable-while-trackpointing is enabled.
+	// It can be "enabled" or "disabled"
+	DWTP LibInputState `json:"dwtp,omitempty"`
 
 	// An array of 6 floats representing the calibration matrix for absolute
 	// devices such as touchscreens
 	CalibrationMatrix [6]float64 `json:"calibration_matrix,omitempty"`
+
+	// Fields sent by sway that are not modeled by LibInput. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 type Input struct {
@@ -617,9 +1336,9 @@ type Input struct {
 	// The product code for the input device
 	Product int64 `json:"product,omitempty"`
 
-	// The device type. Currently this can be "keyboard", "pointer", "touch",
-	// "tablet_tool", "tablet_pad", or "switch"
-	Type string `json:"type,omitempty"`
+	// The device type. Currently this can be "keyboard", "pointer", "touchpad",
+	// "touch", "tablet_tool", "tablet_pad", or "switch"
+	Type InputType `json:"type,omitempty"`
 
 	// (Only keyboards) The name of the active keyboard layout in use
 	XKBActiveLayoutName *string `json:"xkb_active_layout_name,omitempty"`
@@ -630,8 +1349,16 @@ type Input struct {
 	// (Only keyboards) The index of the active keyboard layout in use
 	XKBActiveLayoutIndex *int64 `json:"xkb_active_layout_index,omitempty"`
 
+	// (Only pointers) The scroll factor applied to scroll events
+	ScrollFactor *float64 `json:"scroll_factor,omitempty"`
+
 	// (Only libinput devices) An object describing the current device settings.
 	LibInput *LibInput `json:"libinput,omitempty"`
+
+	// Fields sent by sway that are not modeled by Input. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 type Seat struct {
@@ -650,6 +1377,11 @@ type Seat struct {
 	// Currently, this is an array of objects that are identical to those
 	// returned by GET_INPUTS
 	Devices []Input `json:"devices,omitempty"`
+
+	// Fields sent by sway that are not modeled by Seat. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 // ModeEvent is sent whenever the binding mode changes
@@ -659,6 +1391,11 @@ type ModeEvent struct {
 
 	// Whether the mode should be parsed as pango markup
 	PangoMarkup bool `json:"pango_markup,omitempty"`
+
+	// Fields sent by sway that are not modeled by ModeEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 type Binding struct {
@@ -679,7 +1416,7 @@ type Binding struct {
 
 	// The input type that triggered the binding. This is either "keyboard" or
 	// "mouse"
-	InputType string `json:"input_type,omitempty"`
+	InputType BindingInputType `json:"input_type,omitempty"`
 }
 
 // BindingEvent is sent whenever a binding is executed
@@ -688,6 +1425,11 @@ type BindingEvent struct {
 	Change string `json:"change,omitempty"`
 
 	Binding Binding `json:"binding,omitempty"`
+
+	// Fields sent by sway that are not modeled by BindingEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 // TickEvent is sent when first subscribing to tick events or by a SEND_TICK
@@ -699,6 +1441,11 @@ type TickEvent struct {
 	// The payload given with a SEND_TICK message, if any.
 	// Otherwise, an empty string
 	Payload string `json:"payload,omitempty"`
+
+	// Fields sent by sway that are not modeled by TickEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 // BarStateUpdateEvent is sent when the visibility of a bar changes due to a
@@ -709,6 +1456,11 @@ type BarStateUpdateEvent struct {
 
 	// Whether the bar should be made visible due to a modifier being pressed
 	VisibleByModifier bool `json:"visible_by_modifier,omitempty"`
+
+	// Fields sent by sway that are not modeled by BarStateUpdateEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
 
 // Deprecated: BarStatusUpdateEvent is deprecated, use BarStateUpdateEvent instead
@@ -731,4 +1483,9 @@ type InputEvent struct {
 	// An object representing the input that is identical the ones
 	// GET_INPUTS gives
 	Input Input `json:"input,omitempty"`
+
+	// Fields sent by sway that are not modeled by InputEvent. They are included
+	// again when marshaling.
+	Extra map[string]json.RawMessage `json:"-"`
+	sent  fieldSet
 }
@@ -0,0 +1,216 @@
+package sway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joshuarubin/go-sway/command"
+)
+
+// The functions below run workspace commands and return the state of the
+// affected workspaces afterwards. They use --no-auto-back-and-forth so that
+// switching to the focused workspace never switches away from it.
+
+// SwitchToWorkspace switches to the named workspace, creating it if needed
+func SwitchToWorkspace(ctx context.Context, c Client, name string) (*Workspace, error) {
+	if err := runCommands(ctx, c, command.New("workspace", "--no-auto-back-and-forth", name)); err != nil {
+		return nil, err
+	}
+
+	return FocusedWorkspace(ctx, c)
+}
+
+// SwitchToWorkspaceNumber switches to the workspace with the given number,
+// creating it if needed
+func SwitchToWorkspaceNumber(ctx context.Context, c Client, num int64) (*Workspace, error) {
+	cmd := command.New("workspace", "--no-auto-back-and-forth", "number", fmt.Sprint(num))
+	if err := runCommands(ctx, c, cmd); err != nil {
+		return nil, err
+	}
+
+	return FocusedWorkspace(ctx, c)
+}
+
+// NextWorkspaceOnOutput switches to the next workspace on the focused output
+func NextWorkspaceOnOutput(ctx context.Context, c Client) (*Workspace, error) {
+	if err := runCommands(ctx, c, command.WorkspaceNext(true)); err != nil {
+		return nil, err
+	}
+
+	return FocusedWorkspace(ctx, c)
+}
+
+// PrevWorkspaceOnOutput switches to the previous workspace on the focused
+// output
+func PrevWorkspaceOnOutput(ctx context.Context, c Client) (*Workspace, error) {
+	if err := runCommands(ctx, c, command.WorkspacePrev(true)); err != nil {
+		return nil, err
+	}
+
+	return FocusedWorkspace(ctx, c)
+}
+
+// MoveToWorkspace moves the focused container, or the containers matched by
+// criteria, to the named workspace and returns that workspace
+func MoveToWorkspace(ctx context.Context, c Client, name string, criteria ...command.Criterion) (*Workspace, error) {
+	cmd := command.New("move", "container", "to", "workspace", "--no-auto-back-and-forth", name).With(criteria...)
+	if err := runCommands(ctx, c, cmd); err != nil {
+		return nil, err
+	}
+
+	return findWorkspace(ctx, c, fmt.Sprintf("%q", name), func(ws *Workspace) bool {
+		return ws.Name == name
+	})
+}
+
+// MoveToWorkspaceNumber moves the focused container, or the containers matched
+// by criteria, to the workspace with the given number and returns that
+// workspace
+func MoveToWorkspaceNumber(ctx context.Context, c Client, num int64, criteria ...command.Criterion) (*Workspace, error) {
+	cmd := command.New("move", "container", "to", "workspace", "--no-auto-back-and-forth", "number", fmt.Sprint(num)).With(criteria...)
+	if err := runCommands(ctx, c, cmd); err != nil {
+		return nil, err
+	}
+
+	return findWorkspace(ctx, c, fmt.Sprintf("number %d", num), func(ws *Workspace) bool {
+		return ws.Num == num
+	})
+}
+
+// RenameWorkspace renames a workspace. If from is empty, the focused workspace
+// is renamed.
+func RenameWorkspace(ctx context.Context, c Client, from, to string) (*Workspace, error) {
+	if err := runCommands(ctx, c, command.RenameWorkspace(from, to)); err != nil {
+		return nil, err
+	}
+
+	return findWorkspace(ctx, c, fmt.Sprintf("%q", to), func(ws *Workspace) bool {
+		return ws.Name == to
+	})
+}
+
+// MoveWorkspaceToOutput moves the focused workspace to the named output, or to
+// the output in a direction such as "left", and returns the workspace
+func MoveWorkspaceToOutput(ctx context.Context, c Client, output string) (*Workspace, error) {
+	if err := runCommands(ctx, c, command.MoveWorkspaceToOutput(output)); err != nil {
+		return nil, err
+	}
+
+	return FocusedWorkspace(ctx, c)
+}
+
+// SwapWorkspaces swaps the visible workspaces of two outputs. It returns the
+// workspaces that are visible on outputs a and b afterwards. The workspace
+// that was visible on a is focused.
+func SwapWorkspaces(ctx context.Context, c Client, a, b string) (*Workspace, *Workspace, error) {
+	outputs, err := c.GetOutputs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var wsA, wsB string
+	for _, o := range outputs {
+		switch o.Name {
+		case a:
+			wsA = o.CurrentWorkspace
+		case b:
+			wsB = o.CurrentWorkspace
+		}
+	}
+
+	if wsA == "" {
+		return nil, nil, fmt.Errorf("output %q has no visible workspace", a)
+	}
+
+	if wsB == "" {
+		return nil, nil, fmt.Errorf("output %q has no visible workspace", b)
+	}
+
+	err = runCommands(ctx, c,
+		command.New("workspace", "--no-auto-back-and-forth", wsB),
+		command.MoveWorkspaceToOutput(a),
+		command.New("workspace", "--no-auto-back-and-forth", wsA),
+		command.MoveWorkspaceToOutput(b),
+		command.New("workspace", "--no-auto-back-and-forth", wsB),
+		command.New("workspace", "--no-auto-back-and-forth", wsA),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workspaces, err := c.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var onA, onB *Workspace
+	for i := range workspaces {
+		ws := &workspaces[i]
+		if !ws.Visible {
+			continue
+		}
+
+		switch ws.Output {
+		case a:
+			onA = ws
+		case b:
+			onB = ws
+		}
+	}
+
+	if onA == nil || onB == nil {
+		return nil, nil, fmt.Errorf("outputs %q and %q do not both have a visible workspace", a, b)
+	}
+
+	return onA, onB, nil
+}
+
+// NextFreeWorkspaceNumber returns the lowest workspace number that isn't used
+// by any workspace
+func NextFreeWorkspaceNumber(ctx context.Context, c Client) (int64, error) {
+	workspaces, err := c.GetWorkspaces(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	used := map[int64]bool{}
+	for _, ws := range workspaces {
+		used[ws.Num] = true
+	}
+
+	num := int64(1)
+	for used[num] {
+		num++
+	}
+
+	return num, nil
+}
+
+// FocusedWorkspace returns the focused workspace
+func FocusedWorkspace(ctx context.Context, c Client) (*Workspace, error) {
+	return findWorkspace(ctx, c, "focused", func(ws *Workspace) bool {
+		return ws.Focused
+	})
+}
+
+func findWorkspace(ctx context.Context, c Client, desc string, match func(*Workspace) bool) (*Workspace, error) {
+	workspaces, err := c.GetWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range workspaces {
+		if match(&workspaces[i]) {
+			return &workspaces[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("workspace %s not found", desc)
+}
+
+// runCommands runs cmds in a single message and returns an error if any of
+// them fail
+func runCommands(ctx context.Context, c Client, cmds ...command.Command) error {
+	_, err := RunCommands(ctx, c, command.Join(cmds...))
+	return err
+}
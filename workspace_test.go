@@ -0,0 +1,292 @@
+package sway_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+	"github.com/joshuarubin/go-sway/command"
+)
+
+// fakeWorkspace is a workspace as reported by get_workspaces
+type fakeWorkspace struct {
+	ID      int64  `json:"id"`
+	Num     int64  `json:"num"`
+	Name    string `json:"name"`
+	Visible bool   `json:"visible"`
+	Focused bool   `json:"focused"`
+	Output  string `json:"output"`
+}
+
+// workspaceState tracks the workspaces of a fake sway with outputs eDP-1 and
+// DP-1, and updates them for the workspace commands it runs
+type workspaceState struct {
+	mu         sync.Mutex
+	outputs    []string
+	workspaces []*fakeWorkspace
+	nextID     int64
+}
+
+func newWorkspaceState() *workspaceState {
+	return &workspaceState{
+		outputs: []string{"eDP-1", "DP-1"},
+		workspaces: []*fakeWorkspace{
+			{ID: 10, Num: 1, Name: "1", Visible: true, Focused: true, Output: "eDP-1"},
+			{ID: 11, Num: 2, Name: "2:web", Output: "eDP-1"},
+			{ID: 12, Num: 4, Name: "4", Visible: true, Output: "DP-1"},
+			{ID: 13, Num: -1, Name: "mail", Output: "DP-1"},
+		},
+		nextID: 20,
+	}
+}
+
+func (s *workspaceState) find(match func(*fakeWorkspace) bool) *fakeWorkspace {
+	for _, ws := range s.workspaces {
+		if match(ws) {
+			return ws
+		}
+	}
+	return nil
+}
+
+func (s *workspaceState) focused() *fakeWorkspace {
+	return s.find(func(ws *fakeWorkspace) bool { return ws.Focused })
+}
+
+// lookup returns the workspace named by args, either NAME or number N. It is
+// created on the focused output if it does not exist.
+func (s *workspaceState) lookup(args []string) *fakeWorkspace {
+	name := strings.Join(args, " ")
+	num := int64(-1)
+
+	if len(args) == 2 && args[0] == "number" {
+		name = args[1]
+		num, _ = strconv.ParseInt(name, 10, 64)
+		if ws := s.find(func(ws *fakeWorkspace) bool { return ws.Num == num }); ws != nil {
+			return ws
+		}
+	} else if ws := s.find(func(ws *fakeWorkspace) bool { return ws.Name == name }); ws != nil {
+		return ws
+	} else if n, err := strconv.ParseInt(strings.SplitN(name, ":", 2)[0], 10, 64); err == nil {
+		num = n
+	}
+
+	ws := &fakeWorkspace{ID: s.nextID, Num: num, Name: name, Output: s.focused().Output}
+	s.nextID++
+	s.workspaces = append(s.workspaces, ws)
+	return ws
+}
+
+// show makes ws the visible workspace of its output
+func (s *workspaceState) show(ws *fakeWorkspace) {
+	for _, other := range s.workspaces {
+		if other.Output == ws.Output {
+			other.Visible = false
+		}
+	}
+	ws.Visible = true
+}
+
+func (s *workspaceState) focus(ws *fakeWorkspace) {
+	s.focused().Focused = false
+	ws.Focused = true
+	s.show(ws)
+}
+
+// run runs a single command and returns its error message, if it fails
+func (s *workspaceState) run(cmd string) string {
+	if strings.HasPrefix(cmd, "[") {
+		cmd = cmd[strings.Index(cmd, "] ")+2:]
+	}
+
+	var args []string
+	for _, arg := range strings.Fields(cmd) {
+		if arg != "--no-auto-back-and-forth" {
+			args = append(args, arg)
+		}
+	}
+
+	switch {
+	case len(args) > 1 && args[0] == "workspace":
+		s.focus(s.lookup(args[1:]))
+	case len(args) > 4 && strings.Join(args[:4], " ") == "move container to workspace":
+		s.lookup(args[4:])
+	case len(args) == 5 && strings.Join(args[:4], " ") == "move workspace to output":
+		ws := s.focused()
+		from := ws.Output
+		ws.Output = args[4]
+		s.show(ws)
+
+		// the output that was left shows another of its workspaces
+		if other := s.find(func(o *fakeWorkspace) bool { return o.Output == from }); other != nil {
+			s.show(other)
+		}
+	case len(args) > 3 && args[0] == "rename" && args[1] == "workspace" && args[len(args)-2] == "to":
+		ws := s.focused()
+		if len(args) == 5 {
+			if ws = s.find(func(ws *fakeWorkspace) bool { return ws.Name == args[2] }); ws == nil {
+				return "There is no workspace with that name"
+			}
+		}
+		ws.Name = args[len(args)-1]
+	default:
+		return "Unknown/invalid command"
+	}
+
+	return ""
+}
+
+func (s *workspaceState) handle(typ uint32, payload string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var reply interface{}
+
+	switch typ {
+	case ipcRunCommand:
+		var results []map[string]interface{}
+		for _, cmd := range command.SplitCommands(payload) {
+			if msg := s.run(cmd); msg != "" {
+				results = append(results, map[string]interface{}{"success": false, "error": msg})
+			} else {
+				results = append(results, map[string]interface{}{"success": true})
+			}
+		}
+		reply = results
+	case ipcGetWorkspaces:
+		reply = s.workspaces
+	case ipcGetOutputs:
+		var outputs []map[string]interface{}
+		for _, name := range s.outputs {
+			o := map[string]interface{}{"name": name, "active": true}
+			if ws := s.find(func(ws *fakeWorkspace) bool { return ws.Output == name && ws.Visible }); ws != nil {
+				o["current_workspace"] = ws.Name
+			}
+			outputs = append(outputs, o)
+		}
+		reply = outputs
+	default:
+		return "[]"
+	}
+
+	data, err := json.Marshal(reply)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(data)
+}
+
+func newWorkspaceSway(t *testing.T, fail bool) *fakeSway {
+	state := newWorkspaceState()
+
+	return newFakeSway(t, func(_ *fakeSway, typ uint32, payload string) string {
+		if fail && typ == ipcRunCommand {
+			return `[{"success":false,"error":"No such output"}]`
+		}
+		return state.handle(typ, payload)
+	})
+}
+
+func TestWorkspaceCommands(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newWorkspaceSway(t, false)
+	c := f.client(ctx, t)
+
+	ws, err := sway.SwitchToWorkspace(ctx, c, "2:web")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ws.ID != 11 || !ws.Focused || !ws.Visible {
+		t.Errorf("SwitchToWorkspace returned %+v", ws)
+	}
+
+	ws, err = sway.MoveToWorkspaceNumber(ctx, c, 5, command.ByAppID(`^org\.gnome\.`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the workspace is created on the focused output, which stays focused
+	if ws.Name != "5" || ws.Output != "eDP-1" || ws.Focused || ws.Visible {
+		t.Errorf("MoveToWorkspaceNumber returned %+v", ws)
+	}
+
+	ws, err = sway.RenameWorkspace(ctx, c, "", "2:www")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ws.ID != 11 || ws.Name != "2:www" {
+		t.Errorf("RenameWorkspace returned %+v", ws)
+	}
+
+	var errs sway.CommandErrors
+	if _, err = sway.RenameWorkspace(ctx, c, "missing", "x"); !errors.As(err, &errs) {
+		t.Errorf("expected a command error for a workspace that does not exist, got %v", err)
+	}
+
+	num, err := sway.NextFreeWorkspaceNumber(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if num != 3 {
+		t.Errorf("NextFreeWorkspaceNumber: got %d, want 3", num)
+	}
+
+	a, b, err := sway.SwapWorkspaces(ctx, c, "eDP-1", "DP-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Name != "4" || a.Output != "eDP-1" || a.Focused {
+		t.Errorf("SwapWorkspaces returned %+v for eDP-1", a)
+	}
+
+	if b.Name != "2:www" || b.Output != "DP-1" || !b.Focused {
+		t.Errorf("SwapWorkspaces returned %+v for DP-1", b)
+	}
+
+	want := []string{
+		"workspace --no-auto-back-and-forth 2:web",
+		`[app_id="^org\.gnome\."] move container to workspace --no-auto-back-and-forth number 5`,
+		"rename workspace to 2:www",
+		"rename workspace missing to x",
+		"workspace --no-auto-back-and-forth 4; move workspace to output eDP-1; " +
+			"workspace --no-auto-back-and-forth 2:www; move workspace to output DP-1; " +
+			"workspace --no-auto-back-and-forth 4; workspace --no-auto-back-and-forth 2:www",
+	}
+
+	got := f.Commands()
+	if len(got) != len(want) {
+		t.Fatalf("sent %q, want %q", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("command %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWorkspaceCommandError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := newWorkspaceSway(t, true).client(ctx, t)
+
+	_, err := sway.MoveWorkspaceToOutput(ctx, c, "HDMI-A-9")
+
+	var errs sway.CommandErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Message != "No such output" {
+		t.Errorf("unexpected error %v", err)
+	}
+}
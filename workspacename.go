@@ -0,0 +1,159 @@
+package sway
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WorkspaceName is a workspace name split into the parts of the common
+// "<num>:<icon> <label>" convention, e.g. "3:code" or "1: web"
+type WorkspaceName struct {
+	// Num is the number sway parses from the start of the name, or -1 if the
+	// name does not start with a number
+	Num int64
+
+	// Icon is a symbol, such as an icon font glyph, before the label
+	Icon string
+
+	// Label is the rest of the name
+	Label string
+
+	// the text of the number and the separators as they were parsed, so that
+	// an unchanged name is formatted the same way
+	numText, sep, iconSep string
+	hasSep                bool
+}
+
+// ParseWorkspaceName splits a workspace name into its parts. Like sway, the
+// number is the leading digits of the name. The separators between the parts,
+// such as a colon after the number, are not part of the icon or label, and
+// trailing whitespace is removed.
+func ParseWorkspaceName(name string) WorkspaceName {
+	ret := WorkspaceName{Num: -1}
+
+	digits := len(name) - len(strings.TrimLeft(name, "0123456789"))
+	if digits > 0 {
+		if num, err := strconv.ParseInt(name[:digits], 10, 64); err == nil {
+			ret.Num = num
+			ret.numText = name[:digits]
+			name = name[digits:]
+
+			rest := strings.TrimLeft(strings.TrimPrefix(name, ":"), " ")
+			ret.sep = name[:len(name)-len(rest)]
+			ret.hasSep = strings.TrimSpace(name) != ""
+			name = rest
+		}
+	}
+
+	name = strings.TrimRight(strings.TrimLeft(name, " "), " ")
+
+	if r, size := utf8.DecodeRuneInString(name); size > 0 && isIcon(r) {
+		ret.Icon = name[:size]
+		rest := strings.TrimLeft(name[size:], " ")
+		ret.iconSep = name[size : len(name)-len(rest)]
+		name = rest
+	}
+
+	ret.Label = name
+
+	return ret
+}
+
+func isIcon(r rune) bool {
+	return unicode.In(r, unicode.So, unicode.Co)
+}
+
+// String returns the workspace name, e.g. "3:code", "1: web" or "mail"
+func (n WorkspaceName) String() string {
+	var b strings.Builder
+
+	rest := n.Icon
+	if n.Icon != "" && n.Label != "" {
+		rest += or(n.iconSep, " ")
+	}
+	rest += n.Label
+
+	if n.Num >= 0 {
+		num := strconv.FormatInt(n.Num, 10)
+		if v, err := strconv.ParseInt(n.numText, 10, 64); err == nil && v == n.Num {
+			num = n.numText
+		}
+
+		b.WriteString(num)
+		if rest != "" && n.hasSep {
+			b.WriteString(n.sep)
+		} else if rest != "" {
+			b.WriteByte(':')
+		}
+	}
+
+	b.WriteString(rest)
+
+	return b.String()
+}
+
+func or(s, def string) string {
+	if s != "" {
+		return s
+	}
+	return def
+}
+
+// WithLabel returns a copy of n with a different label. The number and icon
+// are kept.
+func (n WorkspaceName) WithLabel(label string) WorkspaceName {
+	n.Label = strings.TrimSpace(label)
+	return n
+}
+
+// reservedWorkspaceNames are arguments of the workspace command, so
+// workspaces with these names can't be switched to by name
+var reservedWorkspaceNames = []string{
+	"next", "prev", "next_on_output", "prev_on_output", "back_and_forth", "current", "number",
+}
+
+// Validate returns an error if sway would not accept n as a workspace name
+func (n WorkspaceName) Validate() error {
+	name := n.String()
+
+	switch {
+	case name == "":
+		return fmt.Errorf("workspace name is empty")
+	case strings.HasPrefix(name, "__"):
+		return fmt.Errorf("workspace name %q is reserved", name)
+	}
+
+	for _, r := range reservedWorkspaceNames {
+		if strings.EqualFold(name, r) {
+			return fmt.Errorf("workspace name %q is reserved", name)
+		}
+	}
+
+	return nil
+}
+
+// Less returns true if sway orders n before o: numbered workspaces come first,
+// by number, followed by the other workspaces. Workspaces without a number, or
+// with the same number, are not ordered.
+func (n WorkspaceName) Less(o WorkspaceName) bool {
+	switch {
+	case n.Num >= 0 && o.Num >= 0:
+		return n.Num < o.Num
+	case n.Num >= 0:
+		return true
+	}
+	return false
+}
+
+// SortWorkspaces sorts workspaces in the order sway shows them on an output.
+// The sort is stable, so workspaces that aren't ordered by Less keep their
+// order.
+func SortWorkspaces(workspaces []Workspace) {
+	sort.SliceStable(workspaces, func(i, j int) bool {
+		return ParseWorkspaceName(workspaces[i].Name).Less(ParseWorkspaceName(workspaces[j].Name))
+	})
+}
@@ -0,0 +1,83 @@
+package sway_test
+
+import (
+	"testing"
+
+	sway "github.com/joshuarubin/go-sway"
+)
+
+func TestParseWorkspaceName(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		num         int64
+		icon, label string
+		str         string
+	}{
+		{"1", 1, "", "", "1"},
+		{"3:code", 3, "", "code", "3:code"},
+		{"10:chat ", 10, "", "chat", "10:chat"},
+		{"2: web", 2, "", "web", "2: web"},
+		{"007", 7, "", "", "007"},
+		{"1abc", 1, "", "abc", "1abc"},
+		{"4:  dev", 4, "", "dev", "4:  dev"},
+		{"5:♫", 5, "♫", "", "5:♫"},
+		{"mail", -1, "", "mail", "mail"},
+		{"♫ music", -1, "♫", "music", "♫ music"},
+	} {
+		n := sway.ParseWorkspaceName(tc.name)
+
+		if n.Num != tc.num || n.Icon != tc.icon || n.Label != tc.label {
+			t.Errorf("%q: got %d %q %q, want %d %q %q", tc.name, n.Num, n.Icon, n.Label, tc.num, tc.icon, tc.label)
+		}
+
+		if got := n.String(); got != tc.str {
+			t.Errorf("%q: String returned %q, want %q", tc.name, got, tc.str)
+		}
+	}
+}
+
+func TestWorkspaceNameWithLabel(t *testing.T) {
+	for name, want := range map[string]string{
+		"3:code":     "3:editor",
+		"1":          "1:editor",
+		"2: ♫ music": "2: ♫ editor",
+		"mail":       "editor",
+	} {
+		if got := sway.ParseWorkspaceName(name).WithLabel("editor").String(); got != want {
+			t.Errorf("%q: got %q, want %q", name, got, want)
+		}
+	}
+
+	if got := (sway.WorkspaceName{Num: 5, Label: "x"}).String(); got != "5:x" {
+		t.Errorf("got %q, want 5:x", got)
+	}
+}
+
+func TestWorkspaceNameValidate(t *testing.T) {
+	for name, ok := range map[string]bool{
+		"1":            true,
+		"mail":         true,
+		"":             false,
+		"next":         false,
+		"__i3_scratch": false,
+	} {
+		if err := sway.ParseWorkspaceName(name).Validate(); (err == nil) != ok {
+			t.Errorf("%q: got error %v, want ok %v", name, err, ok)
+		}
+	}
+}
+
+func TestSortWorkspaces(t *testing.T) {
+	workspaces := []sway.Workspace{
+		{Name: "mail"}, {Name: "10:chat"}, {Name: "2"}, {Name: "music"}, {Name: "1:web"}, {Name: "2:x"},
+	}
+
+	sway.SortWorkspaces(workspaces)
+
+	want := []string{"1:web", "2", "2:x", "10:chat", "mail", "music"}
+	for i, ws := range workspaces {
+		if ws.Name != want[i] {
+			t.Fatalf("got %v, want %v", workspaces, want)
+		}
+	}
+}